@@ -5,11 +5,16 @@
 package interval
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"math/rand"
 	"os"
 	"path"
 	"runtime"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -48,6 +53,12 @@ func use(...interface{}) {}
 
 var int128 mathutil.Int128
 
+func randInterval(rng *rand.Rand) *interval {
+	a := negInf + 10 + 10*rng.Intn(10)
+	b := a + 10*(1+rng.Intn(3))
+	return &interval{classes[rng.Intn(len(classes))], a, b}
+}
+
 func TestIntersection(t *testing.T) {
 	i := 0
 	for xa := negInf; xa <= posInf; xa += 10 {
@@ -153,6 +164,558 @@ func TestUnion(t *testing.T) {
 	t.Log(i)
 }
 
+func TestHull(t *testing.T) {
+	i := 0
+	for xa := negInf; xa <= posInf; xa += 10 {
+		for xb := xa + 10; xb <= posInf; xb += 10 {
+			for _, xc := range classes {
+				if (xa < negInf+10 || xb > posInf-10) && xc != Unbounded {
+					continue
+				}
+
+				x := &interval{xc, xa, xb}
+				for ya := negInf; ya <= posInf; ya += 10 {
+					for yb := ya + 10; yb <= posInf; yb += 10 {
+						for _, yc := range classes {
+							if (ya < negInf+10 || yb > posInf-10) && yc != Unbounded {
+								continue
+							}
+
+							i++
+							y := &interval{yc, ya, yb}
+							m := map[int]bool{}
+							first, last := posInf+1, negInf-1
+							for n := negInf; n <= posInf; n += 5 {
+								if x.has(n) || y.has(n) {
+									if n < first {
+										first = n
+									}
+									last = n
+								}
+							}
+							for n := first; n <= last; n += 5 {
+								m[n] = true
+							}
+							result := Hull(x, y).(*interval)
+							for n := negInf; n <= posInf; n += 5 {
+								if g, e := result.has(n), m[n]; g != e {
+									t.Log(hash(x, y))
+									t.Log(result)
+									for n := negInf; n <= posInf; n += 5 {
+										t.Log(n, x.has(n), y.has(n), result.has(n), m[n])
+									}
+									t.Fatalf("%v, %d: %v %v %v %v", i, n, x, y, g, e)
+								}
+							}
+							x2 := &Int{xc, xa, xb}
+							y2 := &Int{yc, ya, yb}
+							result2 := Hull(x2, y2).(*Int)
+							if g, e := result.String(), result2.String(); g != e {
+								t.Fatal(x, y, g, e)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	t.Log(i)
+}
+
+func ExampleHull() {
+	fmt.Println(
+		Hull(),
+		Hull(&Int{LeftOpen, 1, 2}),
+		Hull(&Int{LeftOpen, 1, 2}, &Int{Empty, 0, 0}, &Int{Open, 5, 9}),
+		Hull(&Int{Degenerate, 7, 0}, &Int{Degenerate, 3, 0}, &Int{RightBoundedOpen, 0, 1}),
+	)
+	// Output:
+	// <nil> (1, 2] (1, 9) (-∞, 7]
+}
+
+func TestGap(t *testing.T) {
+	i := 0
+	for xa := negInf; xa <= posInf; xa += 10 {
+		for xb := xa + 10; xb <= posInf; xb += 10 {
+			for _, xc := range classes {
+				if (xa < negInf+10 || xb > posInf-10) && xc != Unbounded {
+					continue
+				}
+
+				x := &interval{xc, xa, xb}
+				for ya := negInf; ya <= posInf; ya += 10 {
+					for yb := ya + 10; yb <= posInf; yb += 10 {
+						for _, yc := range classes {
+							if (ya < negInf+10 || yb > posInf-10) && yc != Unbounded {
+								continue
+							}
+
+							i++
+							y := &interval{yc, ya, yb}
+							m := map[int]bool{}
+							first, last := posInf+1, negInf-1
+							for n := negInf; n <= posInf; n += 5 {
+								if x.has(n) || y.has(n) {
+									if n < first {
+										first = n
+									}
+									last = n
+								}
+							}
+							for n := first; n <= last; n += 5 {
+								m[n] = !x.has(n) && !y.has(n)
+							}
+							result := Gap(x, y).(*interval)
+							for n := negInf; n <= posInf; n += 5 {
+								if g, e := result.has(n), m[n]; g != e {
+									t.Log(hash(x, y))
+									t.Log(result)
+									for n := negInf; n <= posInf; n += 5 {
+										t.Log(n, x.has(n), y.has(n), result.has(n), m[n])
+									}
+									t.Fatalf("%v, %d: %v %v %v %v", i, n, x, y, g, e)
+								}
+							}
+							x2 := &Int{xc, xa, xb}
+							y2 := &Int{yc, ya, yb}
+							result2 := Gap(x2, y2).(*Int)
+							if g, e := result.String(), result2.String(); g != e {
+								t.Fatal(x, y, g, e)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	t.Log(i)
+}
+
+func ExampleGap() {
+	x := &Int{LeftClosed, 1, 3}
+	y := &Int{LeftOpen, 5, 9}
+	fmt.Println(Union(x, y), Gap(x, y), Gap(y, x), Gap(y, &Int{Open, 9, 10}))
+	fmt.Println(Gap(&Int{Open, 5, 9}, &Int{Open, 9, 10}))
+	// Output:
+	// <nil> [3, 5] [3, 5] {}
+	// {9}
+}
+
+func TestSplit(t *testing.T) {
+	for xa := negInf + 10; xa <= posInf-10; xa += 10 {
+		for xb := xa + 10; xb <= posInf-10; xb += 10 {
+			for _, xc := range classes {
+				x := &interval{xc, xa, xb}
+				for at := negInf + 5; at <= posInf-5; at += 5 {
+					for _, side := range []Side{Left, Right} {
+						l0, r0 := Split(x, &interval{Degenerate, at, 0}, side)
+						l, r := l0.(*interval), r0.(*interval)
+						for n := negInf; n <= posInf; n += 5 {
+							el := x.has(n) && (n < at || n == at && side == Left)
+							er := x.has(n) && (n > at || n == at && side == Right)
+							if g, e := l.has(n), el; g != e {
+								t.Fatalf("%v %v %v %v: left %v has %v: %v", x, at, side, n, l, g, e)
+							}
+
+							if g, e := r.has(n), er; g != e {
+								t.Fatalf("%v %v %v %v: right %v has %v: %v", x, at, side, n, r, g, e)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Bounds of BigInt not used by the class may be nil.
+	n := big.NewInt
+	at := &BigInt{Degenerate, n(3), nil}
+	for i, test := range []struct {
+		x    *BigInt
+		side Side
+		l, r string
+	}{
+		{&BigInt{Closed, n(1), n(5)}, Left, "[1, 3]", "(3, 5]"},
+		{&BigInt{Closed, n(1), n(5)}, Right, "[1, 3)", "[3, 5]"},
+		{&BigInt{LeftBoundedClosed, n(1), nil}, Left, "[1, 3]", "(3, ∞)"},
+		{&BigInt{RightBoundedOpen, nil, n(5)}, Right, "(-∞, 3)", "[3, 5)"},
+		{&BigInt{Unbounded, nil, nil}, Left, "(-∞, 3]", "(3, ∞)"},
+		{&BigInt{Degenerate, n(3), nil}, Right, "{}", "{3}"},
+	} {
+		l, r := Split(test.x, at, test.side)
+		if g, e := fmt.Sprint(l), test.l; g != e {
+			t.Fatalf("%v: left got %v, expected %v", i, g, e)
+		}
+
+		if g, e := fmt.Sprint(r), test.r; g != e {
+			t.Fatalf("%v: right got %v, expected %v", i, g, e)
+		}
+	}
+	if at.B != nil {
+		t.Fatal("cut point modified")
+	}
+}
+
+func TestDifference(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 10000; i++ {
+		x, y := randInterval(rng), randInterval(rng)
+		if rng.Intn(10) == 0 {
+			y.cls = Empty
+		}
+		d := Difference(x, y)
+		for j, z := range d {
+			if z.Class() == Empty || j > 0 && compareEdges(upper(d[j-1]), lower(z)) > 0 {
+				t.Fatalf("%v - %v: got %v", x, y, d)
+			}
+		}
+		for n := negInf; n <= posInf+20; n++ {
+			var g bool
+			for _, z := range d {
+				g = g || z.(*interval).has(n)
+			}
+			if e := x.has(n) && !y.has(n); g != e {
+				t.Fatalf("%v - %v = %v: %v: got %v, expected %v", x, y, d, n, g, e)
+			}
+		}
+	}
+}
+
+func ExampleSplitAll() {
+	x := &Int{LeftBoundedOpen, 0, 0}
+	cuts := []Interface{&Int{Degenerate, 10, 0}, &Int{Degenerate, 20, 0}, &Int{Degenerate, 30, 0}}
+	fmt.Println(SplitAll(x, cuts, Right))
+	fmt.Println(SplitAll(&Int{Closed, 12, 25}, cuts, Left))
+	// Output:
+	// [(0, 10) [10, 20) [20, 30) [30, ∞)]
+	// [{} [12, 20] (20, 25] {}]
+}
+
+func TestTransform(t *testing.T) {
+	must := func(x interface{}, err error) string {
+		if err != nil {
+			return err.Error()
+		}
+
+		return fmt.Sprint(x)
+	}
+	t0 := time.Date(2015, 1, 1, 10, 0, 0, 0, time.UTC)
+	tm := &Time{LeftClosed, t0, t0.Add(time.Hour)}
+	for i, test := range []struct{ g, e string }{
+		{must(Translate(&Int64{LeftOpen, 1, 2}, -3)), "(-2, -1]"},
+		{must(Translate(&Int8{LeftBoundedClosed, 100, 0}, 27)), "[127, ∞)"},
+		{must(Translate(&Int8{LeftBoundedClosed, 100, 0}, 28)), "interval: overflow"},
+		{must(Translate(&Int8{RightBoundedOpen, 100, -100}, -28)), "(-∞, -128)"},
+		{must(Translate(&Int8{RightBoundedOpen, 100, -100}, -29)), "interval: overflow"},
+		{must(Translate(&Uint16{Closed, 1, 65535}, 1)), "interval: overflow"},
+		{must(Translate(&Float64{Open, 1, 2}, 0.5)), "(1.5, 2.5)"},
+		{must(Translate(&Duration{Closed, 1, 2}, time.Second)), "[1.000000001s, 1.000000002s]"},
+		{must(Scale(&Int{LeftOpen, 1, 2}, 0, -1)), "[-2, -1)"},
+		{must(Scale(&Int{LeftOpen, 1, 3}, 2, 2)), "(0, 4]"},
+		{must(Scale(&Int{LeftBoundedOpen, 1, 3}, 2, -2)), "(-∞, 4)"},
+		{must(Scale(&Int{Degenerate, 1, 3}, 2, -2)), "{4}"},
+		{must(Scale(&Int{Unbounded, 1, 3}, 2, 0)), "{2}"},
+		{must(Scale(&Int{Empty, 1, 3}, 2, 0)), "{}"},
+		{must(Scale(&Uint{Closed, 2, 5}, 3, 3)), "[0, 9]"},
+		{must(Scale(&Uint{Closed, 1, 5}, 3, 2)), "interval: overflow"},
+		{must(Scale(&Byte{Closed, 0, 200}, 0, 2)), "interval: overflow"},
+		{must(Scale(&Int8{Closed, -100, 100}, 0, -1)), "[-100, 100]"},
+		{must(Scale(&Int8{Closed, -128, 100}, 0, -1)), "interval: overflow"},
+		{must(Scale(&Int8{Closed, 100, 127}, -100, 1)), "[100, 127]"},
+		{must(Scale(&Int8{Closed, 100, 127}, -100, 2)), "interval: overflow"},
+		{must(Scale(&Int8{Closed, -128, 127}, 127, 1)), "[-128, 127]"},
+		{must(Scale(&Uint64{Closed, math.MaxUint64 - 1, math.MaxUint64}, math.MaxUint64, 1)), "[18446744073709551614, 18446744073709551615]"},
+		{must(Scale(&Float32{Open, 1, 2}, 0, 0.5)), "(0.5, 1)"},
+		{must(Expand(&Int{LeftClosed, 1, 2}, 1)), "[0, 3)"},
+		{must(Expand(&Int{Degenerate, 1, 2}, 1)), "[0, 2]"},
+		{must(Expand(&Int{RightBoundedClosed, 1, 2}, 1)), "(-∞, 3]"},
+		{must(Expand(&Int{Closed, 1, 2}, -1)), "interval: negative margin"},
+		{must(Expand(&Byte{Closed, 1, 2}, 2)), "interval: overflow"},
+		{must(Shrink(&Int{LeftClosed, 1, 5}, 1)), "[2, 4)"},
+		{must(Shrink(&Int{Closed, 1, 5}, 2)), "{3}"},
+		{must(Shrink(&Int{LeftOpen, 1, 5}, 2)), "{}"},
+		{must(Shrink(&Int{Open, 1, 5}, 3)), "{}"},
+		{must(Shrink(&Int{Degenerate, 1, 5}, 1)), "{}"},
+		{must(Shrink(&Int{Degenerate, 1, 5}, 0)), "{1}"},
+		{must(Shrink(&Int8{Closed, -128, 127}, 127)), "[-1, 0]"},
+		{must(Shrink(&Int8{Closed, 120, 127}, 10)), "{}"},
+		{must(Shrink(&Int8{LeftBoundedOpen, 120, 127}, 10)), "interval: overflow"},
+		{must(Shrink(&Uint{LeftOpen, 1, 5}, 1)), "(2, 4]"},
+		{must(tm.Translate(-time.Hour)), "[2015-01-01 09:00:00 +0000 UTC, 2015-01-01 10:00:00 +0000 UTC)"},
+		{must(tm.Scale(t0, 2)), "[2015-01-01 10:00:00 +0000 UTC, 2015-01-01 12:00:00 +0000 UTC)"},
+		{must(tm.Scale(t0, -1)), "(2015-01-01 09:00:00 +0000 UTC, 2015-01-01 10:00:00 +0000 UTC]"},
+		{must((&Time{Closed, t0, t0.AddDate(0, 0, 73000)}).Scale(t0, 2)), "[2015-01-01 10:00:00 +0000 UTC, 2414-09-26 10:00:00 +0000 UTC]"},
+		{must((&Time{Closed, t0, t0.AddDate(0, 0, 146000)}).Scale(t0, 0.5)), "[2015-01-01 10:00:00 +0000 UTC, 2214-11-14 10:00:00 +0000 UTC]"},
+		{must(tm.Scale(time.Time{}, 1)), "[2015-01-01 10:00:00 +0000 UTC, 2015-01-01 11:00:00 +0000 UTC)"},
+		{must(tm.Scale(time.Time{}, 1e300)), "interval: overflow"},
+		{must((&Time{LeftBoundedClosed, t0, time.Time{}}).Scale(t0.Add(-time.Hour), 2)), "[2015-01-01 11:00:00 +0000 UTC, ∞)"},
+		{must(tm.Expand(15 * time.Minute)), "[2015-01-01 09:45:00 +0000 UTC, 2015-01-01 11:15:00 +0000 UTC)"},
+		{must(tm.Shrink(15 * time.Minute)), "[2015-01-01 10:15:00 +0000 UTC, 2015-01-01 10:45:00 +0000 UTC)"},
+		{must(tm.Shrink(30 * time.Minute)), "{}"},
+	} {
+		if test.g != test.e {
+			t.Errorf("%v: got %q, expected %q", i, test.g, test.e)
+		}
+	}
+}
+
+func TestClamp(t *testing.T) {
+	f := func(v interface{}, ok bool) string { return fmt.Sprint(v, ok) }
+	t0 := time.Date(2015, 1, 1, 10, 0, 0, 0, time.UTC)
+	tm := &Time{LeftClosed, t0, t0.Add(time.Hour)}
+	for i, test := range []struct{ g, e string }{
+		{f((&Int{Closed, 1, 5}).Clamp(3)), "3 true"},
+		{f((&Int{Closed, 1, 5}).Clamp(-3)), "1 true"},
+		{f((&Int{Closed, 1, 5}).Clamp(7)), "5 true"},
+		{f((&Int{Open, 1, 5}).Clamp(-3)), "2 true"},
+		{f((&Int{Open, 1, 5}).Clamp(7)), "4 true"},
+		{f((&Int{Open, 1, 2}).Clamp(7)), "7 false"},
+		{f((&Int{Open, 1, 3}).Clamp(7)), "2 true"},
+		{f((&Int{Empty, 1, 3}).Clamp(7)), "7 false"},
+		{f((&Int{Degenerate, 1, 3}).Clamp(7)), "1 true"},
+		{f((&Int{Unbounded, 1, 3}).Clamp(7)), "7 true"},
+		{f((&Int{LeftBoundedOpen, 1, 3}).Clamp(-7)), "2 true"},
+		{f((&Int{RightBoundedOpen, 1, 3}).Clamp(7)), "2 true"},
+		{f((&Int{RightBoundedClosed, 1, 3}).Clamp(-7)), "-7 true"},
+		{f((&Int8{LeftBoundedOpen, 127, 0}).Clamp(0)), "0 false"},
+		{f((&Uint{RightBoundedOpen, 0, 0}).Clamp(0)), "0 false"},
+		{f((&Float64{LeftOpen, 1, 2}).Clamp(0)), "1.0000000000000002 true"},
+		{f((&Float64{LeftOpen, 1, 2}).Clamp(3)), "2 true"},
+		{f((&Float32{LeftClosed, 1, 2}).Clamp(3)), "1.9999999 true"},
+		{f((&Duration{Open, 0, time.Second}).Clamp(-time.Hour)), "1ns true"},
+		{f(tm.Clamp(t0.Add(2 * time.Hour))), "2015-01-01 10:59:59.999999999 +0000 UTC true"},
+		{f((&Int8{Closed, 100, 127}).Distance(-128)), "228 true"},
+		{f((&Int{LeftOpen, 1, 5}).Distance(1)), "1 true"},
+		{f((&Int{LeftOpen, 1, 5}).Distance(4)), "0 true"},
+		{f((&Uint16{LeftOpen, 1, 5}).Distance(9)), "4 true"},
+		{f((&Float64{LeftOpen, 1, 5}).Distance(9)), "4 true"},
+		{f((&Duration{LeftBoundedClosed, math.MaxInt64, 0}).Distance(math.MinInt64)), "2562047h47m16.854775807s true"},
+		{f(tm.Distance(t0.Add(-time.Minute))), "1m0s true"},
+		{f(tm.Distance(t0.Add(2 * time.Hour))), "1h0m0.000000001s true"},
+		{f((&Int128{Open, mathutil.Int128{Lo: 1}, mathutil.Int128{Lo: 5}}).Clamp(mathutil.Int128{Lo: -3, Hi: -1})), "2 true"},
+		{f((&Int128{LeftClosed, mathutil.Int128{Lo: 1}, mathutil.Int128{Lo: 5}}).Clamp(mathutil.Int128{Hi: 1})), "4 true"},
+		{f((&Int128{LeftBoundedOpen, mathutil.Int128{Lo: -1, Hi: math.MaxInt64}, mathutil.Int128{}}).Clamp(mathutil.Int128{})), "0 false"},
+		{f((&Int128{RightBoundedOpen, mathutil.Int128{}, mathutil.Int128{Hi: math.MinInt64}}).Clamp(mathutil.Int128{})), "0 false"},
+		{f((&Int128{Closed, mathutil.Int128{Lo: 1}, mathutil.Int128{Lo: 5}}).Distance(mathutil.Int128{Hi: 1})), "18446744073709551611 true"},
+		{f((&Int128{Closed, mathutil.Int128{Lo: 1}, mathutil.Int128{Lo: 5}}).Distance(mathutil.Int128{Lo: -1, Hi: -1})), "2 true"},
+		{f((&Date{Open, CivilDate{2024, 2, 28}, CivilDate{2024, 3, 5}}).Clamp(CivilDate{2024, 1, 1})), "2024-02-29 true"},
+		{f((&Date{LeftClosed, CivilDate{2024, 2, 28}, CivilDate{2024, 3, 1}}).Clamp(CivilDate{2025, 1, 1})), "2024-02-29 true"},
+		{f((&Date{Open, CivilDate{2024, 2, 28}, CivilDate{2024, 2, 29}}).Clamp(CivilDate{2025, 1, 1})), "2025-01-01 false"},
+		{f((&Date{Closed, CivilDate{2024, 2, 28}, CivilDate{2024, 3, 5}}).Distance(CivilDate{2024, 1, 1})), "58 true"},
+		{f((&Date{RightBoundedOpen, CivilDate{}, CivilDate{2024, 3, 1}}).Distance(CivilDate{2025, 3, 1})), "366 true"},
+		{f((&Date{Closed, CivilDate{2024, 2, 28}, CivilDate{2024, 3, 5}}).Distance(CivilDate{2024, 3, 1})), "0 true"},
+		{f((&BigInt{Open, big.NewInt(1), big.NewInt(5)}).Clamp(big.NewInt(-3))), "2 true"},
+		{f((&BigInt{LeftBoundedClosed, big.NewInt(1), nil}).Clamp(big.NewInt(7))), "7 true"},
+		{f((&BigInt{RightBoundedOpen, nil, big.NewInt(5)}).Clamp(big.NewInt(7))), "4 true"},
+		{f((&BigInt{Open, big.NewInt(1), big.NewInt(2)}).Clamp(big.NewInt(7))), "7 false"},
+		{f((&BigInt{Closed, big.NewInt(1), big.NewInt(5)}).Distance(big.NewInt(-3))), "4 true"},
+		{f((&BigInt{LeftOpen, big.NewInt(1), big.NewInt(5)}).Distance(big.NewInt(3))), "0 true"},
+	} {
+		if test.g != test.e {
+			t.Errorf("%v: got %q, expected %q", i, test.g, test.e)
+		}
+	}
+
+	v := big.NewInt(3)
+	bi := &BigInt{Closed, big.NewInt(1), big.NewInt(5)}
+	c, _ := bi.Clamp(v)
+	c.SetInt64(42)
+	if g, e := fmt.Sprint(v, bi), "3 [1, 5]"; g != e {
+		t.Errorf("got %q, expected %q", g, e)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var a []*interval
+	for xa := negInf + 10; xa <= posInf-10; xa += 10 {
+		for xb := xa + 10; xb <= posInf-10; xb += 10 {
+			for _, xc := range classes {
+				a = append(a, &interval{xc, xa, xb})
+			}
+		}
+	}
+	// key returns whether x is empty and its first and last sampled value.
+	// Sampling at half of the bounds step distinguishes open and closed
+	// bounds.
+	key := func(x *interval) (empty bool, lo, hi int) {
+		lo, hi = posInf+1, negInf-1
+		for n := negInf; n <= posInf; n += 5 {
+			if x.has(n) {
+				if n < lo {
+					lo = n
+				}
+				hi = n
+			}
+		}
+		return lo > hi, lo, hi
+	}
+	for _, x := range a {
+		for _, y := range a {
+			xe, xlo, xhi := key(x)
+			ye, ylo, yhi := key(y)
+			var e int
+			switch {
+			case xe && ye:
+				e = 0
+			case xe:
+				e = -1
+			case ye:
+				e = 1
+			case xlo != ylo:
+				e = cmp(xlo, ylo)
+			default:
+				e = cmp(xhi, yhi)
+			}
+			if g := Compare(x, y); g != e {
+				t.Fatalf("%v %v: got %v, expected %v", x, y, g, e)
+			}
+		}
+	}
+}
+
+func ExampleCompare() {
+	a := []Interface{
+		&Int{LeftOpen, 1, 2},
+		&Int{LeftBoundedClosed, 1, 0},
+		&Int{Closed, 1, 2},
+		&Int{Empty, 0, 0},
+		&Int{RightBoundedOpen, 0, 1},
+		&Int{LeftClosed, 1, 2},
+		&Int{Degenerate, 1, 0},
+	}
+	slices.SortFunc(a, Compare)
+	fmt.Println(a)
+	sort.Sort(sort.Reverse(Slice(a)))
+	fmt.Println(a)
+	// Output:
+	// [{} (-∞, 1) {1} [1, 2) [1, 2] [1, ∞) (1, 2]]
+	// [(1, 2] [1, ∞) [1, 2] [1, 2) {1} (-∞, 1) {}]
+}
+
+func TestMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 10000; i++ {
+		var xs []Interface
+		for j := rng.Intn(8); j > 0; j-- {
+			xs = append(xs, randInterval(rng))
+		}
+		in := fmt.Sprint(xs)
+		r := Merge(xs)
+		if g, e := fmt.Sprint(xs), in; g != e {
+			t.Fatalf("input modified: %s -> %s", e, g)
+		}
+
+		for n := negInf; n <= posInf; n += 5 {
+			var e, g bool
+			for _, x := range xs {
+				e = e || x.(*interval).has(n)
+			}
+			for _, x := range r {
+				g = g || x.(*interval).has(n)
+			}
+			if g != e {
+				t.Fatalf("%v -> %v: %v: got %v, expected %v", xs, r, n, g, e)
+			}
+		}
+		for j, x := range r {
+			if x.Class() == Empty {
+				t.Fatalf("%v -> %v: empty interval", xs, r)
+			}
+
+			if j != 0 && (Compare(r[j-1], x) >= 0 || Union(r[j-1], x) != nil) {
+				t.Fatalf("%v -> %v: not sorted or not disjoint", xs, r)
+			}
+
+			for _, y := range xs {
+				if x == y {
+					t.Fatalf("%v -> %v: shared interval", xs, r)
+				}
+			}
+		}
+	}
+}
+
+func ExampleMerge() {
+	fmt.Println(Merge([]Interface{
+		&Int{LeftClosed, 5, 7},
+		&Int{LeftClosed, 1, 2},
+		&Int{Open, 7, 9},
+		&Int{LeftClosed, 2, 3},
+		&Int{Degenerate, 8, 0},
+		&Int{Empty, 0, 0},
+		&Int{LeftBoundedClosed, 20, 0},
+	}))
+	// Output:
+	// [[1, 3) [5, 7) (7, 9) [20, ∞)]
+}
+
+func TestCoverage(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 10000; i++ {
+		var xs []Interface
+		for j := rng.Intn(8); j > 0; j-- {
+			xs = append(xs, randInterval(rng))
+		}
+		r := Coverage(xs)
+		max := 0
+		for n := negInf; n <= posInf; n += 5 {
+			e := 0
+			for _, x := range xs {
+				if x.(*interval).has(n) {
+					e++
+				}
+			}
+			if e > max {
+				max = e
+			}
+			g := 0
+			for _, s := range r {
+				if s.X.(*interval).has(n) {
+					if g != 0 {
+						t.Fatalf("%v -> %v: %v: overlapping segments", xs, r, n)
+					}
+
+					g = s.Depth
+				}
+			}
+			if g != e {
+				t.Fatalf("%v -> %v: %v: got %v, expected %v", xs, r, n, g, e)
+			}
+		}
+		for j, s := range r {
+			if s.X.Class() == Empty || s.Depth <= 0 {
+				t.Fatalf("%v -> %v: invalid segment", xs, r)
+			}
+
+			if j != 0 && Compare(r[j-1].X, s.X) >= 0 {
+				t.Fatalf("%v -> %v: not sorted", xs, r)
+			}
+
+			if j != 0 && r[j-1].Depth == s.Depth && Union(r[j-1].X, s.X) != nil {
+				t.Fatalf("%v -> %v: not maximal", xs, r)
+			}
+		}
+		if g, _ := MaxDepth(xs); g != max {
+			t.Fatalf("%v: got %v, expected %v", xs, g, max)
+		}
+	}
+}
+
+func ExampleCoverage() {
+	xs := []Interface{
+		&Int{Closed, 1, 3},
+		&Int{LeftClosed, 2, 4},
+		&Int{LeftClosed, 4, 5},
+		&Int{Closed, 7, 8},
+		&Int{Closed, 8, 9},
+	}
+	fmt.Println(Coverage(xs))
+	fmt.Println(MaxDepth(xs))
+	// Output:
+	// [{[1, 2) 1} {[2, 3] 2} {(3, 5) 1} {[7, 8) 1} {{8} 2} {(8, 9] 1}]
+	// 2 [2, 3]
+}
+
 func isDisjointUnion(x, y *interval) bool {
 	const (
 		initial = iota
@@ -187,6 +750,178 @@ func isDisjointUnion(x, y *interval) bool {
 	return false
 }
 
+func TestNew(t *testing.T) {
+	for i, test := range []struct {
+		c    Class
+		a, b int64
+		e    string
+	}{
+		{Unbounded, 1, 2, "(-∞, ∞)"},
+		{Empty, 1, 2, "{}"},
+		{Degenerate, 1, 2, "{1}"},
+		{Open, 1, 2, "(1, 2)"},
+		{Open, 1, 1, "{}"},
+		{Open, 2, 1, ""},
+		{Closed, 1, 2, "[1, 2]"},
+		{Closed, 1, 1, "{1}"},
+		{Closed, 2, 1, ""},
+		{LeftOpen, 1, 2, "(1, 2]"},
+		{LeftOpen, 1, 1, "{}"},
+		{LeftOpen, 2, 1, ""},
+		{LeftClosed, 1, 2, "[1, 2)"},
+		{LeftClosed, 1, 1, "{}"},
+		{LeftClosed, 2, 1, ""},
+		{LeftBoundedOpen, 1, 2, "(1, ∞)"},
+		{LeftBoundedClosed, 1, 2, "[1, ∞)"},
+		{RightBoundedOpen, 1, 2, "(-∞, 2)"},
+		{RightBoundedClosed, 1, 2, "(-∞, 2]"},
+		{nClasses, 1, 2, ""},
+	} {
+		x, err := New[Int64](test.c, test.a, test.b)
+		if test.e == "" {
+			if err == nil {
+				t.Fatalf("%v: %v %v %v: unexpected success", i, test.c, test.a, test.b)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%v: %v", i, err)
+		}
+
+		if g, e := x.String(), test.e; g != e {
+			t.Fatalf("%v: got %q, expected %q", i, g, e)
+		}
+	}
+}
+
+func TestNewBigInt(t *testing.T) {
+	a := big.NewInt(1)
+	x := Must(GreaterThan[BigInt](a))
+	y := Must(LessThan[BigInt](big.NewInt(3)))
+	if g, e := fmt.Sprint(Intersection(x, y)), "(1, 3)"; g != e {
+		t.Fatalf("got %q, expected %q", g, e)
+	}
+
+	if g, e := fmt.Sprint(x), "(1, ∞)"; g != e {
+		t.Fatalf("got %q, expected %q", g, e)
+	}
+
+	if x.A == a || x.B == a || x.A == x.B {
+		t.Fatal("bounds are shared")
+	}
+}
+
+func TestMust(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	Must(ClosedOf[Float64](2, 1))
+}
+
+func ExampleNew() {
+	x := Must(ClosedOf[Int64](1, 5))
+	y := Must(LeftClosedOf[Int64](5, 9))
+	z := Must(AtLeast[Int64](7))
+	_, err := OpenOf[Int64](5, 1)
+	fmt.Println(x, y, z, Intersection(x, y), Intersection(y, z), err)
+	// Output:
+	// [1, 5] [5, 9) [7, ∞) {5} [7, 9) interval: invalid Open interval: a > b
+}
+
+func TestValidate(t *testing.T) {
+	nan := math.NaN()
+	for i, test := range []struct {
+		x     Interface
+		bound string
+		err   error
+	}{
+		{&Int64{Closed, 1, 2}, "", nil},
+		{&Int64{Closed, 2, 1}, "", ErrOrder},
+		{&Int64{LeftOpen, 1, 1}, "", ErrEqual},
+		{&Int64{RightBoundedOpen, 2, 1}, "", nil},
+		{&Int64{nClasses, 1, 2}, "", ErrClass},
+		{&Int64{-1, 1, 2}, "", ErrClass},
+		{&Float64{Open, nan, 2}, "a", ErrNaN},
+		{&Float64{Open, 1, nan}, "b", ErrNaN},
+		{&Float64{LeftBoundedOpen, 1, nan}, "", nil},
+		{&Float32{Degenerate, float32(nan), 1}, "a", ErrNaN},
+		{&BigInt{Closed, big.NewInt(1), nil}, "b", ErrNil},
+		{&BigInt{RightBoundedClosed, nil, big.NewInt(1)}, "", nil},
+		{&BigRat{LeftBoundedClosed, nil, nil}, "a", ErrNil},
+		{&BigRat{Empty, nil, nil}, "", nil},
+		{&interval{Open, 2, 1}, "", ErrOrder},
+	} {
+		err := Validate(test.x)
+		if test.err == nil {
+			if err != nil {
+				t.Fatalf("%v: %v", i, err)
+			}
+
+			continue
+		}
+
+		if !errors.Is(err, test.err) {
+			t.Fatalf("%v: got %v, expected %v", i, err, test.err)
+		}
+
+		if g, e := err.(*InvalidError).Bound, test.bound; g != e {
+			t.Fatalf("%v: got %q, expected %q", i, g, e)
+		}
+	}
+
+	if _, err := ClosedOf[Float64](nan, 1); !errors.Is(err, ErrNaN) {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenOf[BigInt](nil, big.NewInt(1)); !errors.Is(err, ErrNil) {
+		t.Fatal(err)
+	}
+}
+
+func TestTry(t *testing.T) {
+	x := &Int64{Closed, 1, 5}
+	y := &Float64{Closed, 2, 3}
+	_, err := TryIntersection(x, y)
+	if _, ok := err.(*TypeError); !ok {
+		t.Fatalf("%T %v", err, err)
+	}
+
+	if g, e := err.Error(), "interval: mismatched interval types *interval.Int64 and *interval.Float64"; g != e {
+		t.Fatalf("got %q, expected %q", g, e)
+	}
+
+	if _, err = TryUnion(x, &Int64{Open, 3, 2}); !errors.Is(err, ErrOrder) {
+		t.Fatal(err)
+	}
+
+	z, err := TryUnion(x, &Int64{Open, 3, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g, e := fmt.Sprint(z), "[1, 7)"; g != e {
+		t.Fatalf("got %q, expected %q", g, e)
+	}
+
+	if z, err = TryUnion(x, &Int64{Open, 6, 7}); z != nil || err != nil {
+		t.Fatal(z, err)
+	}
+
+	defer func() {
+		err, ok := recover().(*TypeError)
+		if !ok || err.X != x || err.Y != y {
+			t.Fatal(err)
+		}
+	}()
+
+	Intersection(x, y)
+}
+
 func ExampleBigInt() {
 	x := &BigInt{LeftOpen, big.NewInt(1), big.NewInt(2)}
 	y := &BigInt{LeftClosed, big.NewInt(2), big.NewInt(3)}
@@ -0,0 +1,139 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrTransactionTime is returned when recording a change of a Bitemporal
+// before its latest change.
+var ErrTransactionTime = errors.New("interval: transaction time before the latest change")
+
+// Fact is a bitemporal record: Value held in the real world during Valid,
+// according to what was recorded during Recorded.
+type Fact[V any] struct {
+	Value    V
+	Valid    *Time // Valid time.
+	Recorded *Time // Transaction time.
+}
+
+func (f Fact[V]) clone() Fact[V] {
+	return Fact[V]{f.Value, f.Valid.Clone().(*Time), f.Recorded.Clone().(*Time)}
+}
+
+// current reports whether f was not superseded by a later change.
+func (f Fact[V]) current() bool { return f.Recorded.Cls == LeftBoundedClosed }
+
+// Bitemporal is the history of the value of a single thing along two time
+// axes: the valid time, when the value held in the real world, and the
+// transaction time, when the value was recorded. Recording a change never
+// modifies the past, it closes the transaction time of the facts it
+// supersedes and opens new ones, so the state recorded at any transaction time
+// can be recovered later.
+//
+// The zero value is an empty Bitemporal ready to use.
+type Bitemporal[V any] struct {
+	facts []Fact[V]
+	last  time.Time // Latest transaction time.
+}
+
+func containsTime(x *Time, t time.Time) bool {
+	return Intersection(x, &Time{Degenerate, t, t}).Class() != Empty
+}
+
+// update records at the transaction time at that the values during valid are
+// no longer known and, if v is not nil, that the value is *v instead.
+func (b *Bitemporal[V]) update(valid *Time, at time.Time, v *V) error {
+	if at.Before(b.last) {
+		return ErrTransactionTime
+	}
+
+	b.last = at
+	if valid.Cls == Empty {
+		return nil
+	}
+
+	now := &Time{LeftBoundedClosed, at, at}
+	r := b.facts[:0:0]
+	for _, f := range b.facts {
+		if !f.current() || Intersection(f.Valid, valid).Class() == Empty {
+			r = append(r, f)
+			continue
+		}
+
+		if f.Recorded.A.Before(at) {
+			r = append(r, Fact[V]{f.Value, f.Valid, &Time{LeftClosed, f.Recorded.A, at}})
+		}
+		for _, p := range Difference(f.Valid, valid) {
+			r = append(r, Fact[V]{f.Value, p.(*Time), now})
+		}
+	}
+	if v != nil {
+		r = append(r, Fact[V]{*v, valid.Clone().(*Time), now})
+	}
+	b.facts = r
+	return nil
+}
+
+// Assert records at the transaction time at that the value is v during valid,
+// superseding any values recorded for valid before. Asserting a value for a
+// valid time in the past is a correction. If at is before the latest change of
+// b, ErrTransactionTime is returned and b is not modified.
+func (b *Bitemporal[V]) Assert(v V, valid *Time, at time.Time) error { return b.update(valid, at, &v) }
+
+// Retract records at the transaction time at that the value during valid is
+// unknown, superseding any values recorded for valid before. If at is before
+// the latest change of b, ErrTransactionTime is returned and b is not
+// modified.
+func (b *Bitemporal[V]) Retract(valid *Time, at time.Time) error { return b.update(valid, at, nil) }
+
+// AsOf returns the value valid at the time valid according to what was
+// recorded at the transaction time tx. The result is false if there is no such
+// value.
+func (b *Bitemporal[V]) AsOf(tx, valid time.Time) (v V, ok bool) {
+	for _, f := range b.facts {
+		if containsTime(f.Recorded, tx) && containsTime(f.Valid, valid) {
+			return f.Value, true
+		}
+	}
+	return v, false
+}
+
+// Timeline returns the facts recorded at the transaction time tx, in
+// ascending order of their valid time. That is the history of the value as it
+// was known at tx.
+func (b *Bitemporal[V]) Timeline(tx time.Time) []Fact[V] {
+	return b.slice(func(f Fact[V]) bool { return containsTime(f.Recorded, tx) }, func(f Fact[V]) *Time { return f.Valid })
+}
+
+// History returns the facts about the value at the time valid, in ascending
+// order of their transaction time. That is how the knowledge of the value at
+// valid evolved.
+func (b *Bitemporal[V]) History(valid time.Time) []Fact[V] {
+	return b.slice(func(f Fact[V]) bool { return containsTime(f.Valid, valid) }, func(f Fact[V]) *Time { return f.Recorded })
+}
+
+// Facts returns all the facts of b in ascending order of their transaction
+// time and then of their valid time.
+func (b *Bitemporal[V]) Facts() []Fact[V] {
+	r := b.slice(func(Fact[V]) bool { return true }, func(f Fact[V]) *Time { return f.Valid })
+	slices.SortStableFunc(r, func(f, g Fact[V]) int { return Compare(f.Recorded, g.Recorded) })
+	return r
+}
+
+// slice returns clones of the facts selected by f sorted by the intervals
+// returned by key.
+func (b *Bitemporal[V]) slice(f func(Fact[V]) bool, key func(Fact[V]) *Time) (r []Fact[V]) {
+	for _, v := range b.facts {
+		if f(v) {
+			r = append(r, v.clone())
+		}
+	}
+	slices.SortStableFunc(r, func(f, g Fact[V]) int { return Compare(key(f), key(g)) })
+	return r
+}
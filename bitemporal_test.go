@@ -0,0 +1,106 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func factsString[V any](facts []Fact[V]) (s []string) {
+	d := func(t time.Time) string { return t.Format("Jan02") }
+	for _, f := range facts {
+		var v, r string
+		switch x := f.Valid; x.Cls {
+		case LeftClosed:
+			v = d(x.A) + "-" + d(x.B)
+		case LeftBoundedClosed:
+			v = d(x.A) + "-"
+		default:
+			v = x.String()
+		}
+		switch x := f.Recorded; x.Cls {
+		case LeftClosed:
+			r = d(x.A) + "-" + d(x.B)
+		default:
+			r = d(x.A) + "-"
+		}
+		s = append(s, fmt.Sprintf("%v:%s@%s", f.Value, v, r))
+	}
+	return s
+}
+
+func TestBitemporal(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	from := func(t time.Time) *Time { return &Time{LeftBoundedClosed, t, t} }
+	var b Bitemporal[int]
+	t1, t2, t3, t4 := day(1, 5), day(2, 20), day(3, 10), day(4, 1)
+	if err := b.Assert(100, from(day(1, 1)), t1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Assert(120, from(day(3, 1)), t2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Correction of the past.
+	if err := b.Assert(110, &Time{LeftClosed, day(2, 1), day(3, 1)}, t3); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Assert(0, from(day(1, 1)), t2); err != ErrTransactionTime {
+		t.Fatalf("got %v, expected %v", err, ErrTransactionTime)
+	}
+
+	for _, test := range []struct {
+		tx, valid time.Time
+		e         int
+		ok        bool
+	}{
+		{day(1, 1), day(4, 1), 0, false},
+		{t1, day(12, 31), 100, true},
+		{t1, day(4, 1), 100, true},
+		{t2, day(4, 1), 120, true},
+		{t2, day(2, 15), 100, true},
+		{t3, day(2, 15), 110, true},
+		{t3, day(1, 15), 100, true},
+		{t3, day(3, 1), 120, true},
+		{t1, day(1, 1).Add(-time.Nanosecond), 0, false},
+	} {
+		if g, ok := b.AsOf(test.tx, test.valid); g != test.e || ok != test.ok {
+			t.Fatalf("%v %v: got %v %v, expected %v %v", test.tx, test.valid, g, ok, test.e, test.ok)
+		}
+	}
+
+	if g, e := fmt.Sprint(factsString(b.Timeline(t3))), "[100:Jan01-Feb01@Mar10- 110:Feb01-Mar01@Mar10- 120:Mar01-@Feb20-]"; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	if g, e := fmt.Sprint(factsString(b.History(day(2, 15)))), "[100:Jan01-@Jan05-Feb20 100:Jan01-Mar01@Feb20-Mar10 110:Feb01-Mar01@Mar10-]"; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	if err := b.Retract(&Time{LeftClosed, day(1, 1), day(2, 1)}, t4); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same transaction time supersedes without history.
+	if err := b.Assert(130, from(day(6, 1)), t4); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Assert(140, from(day(6, 1)), t4); err != nil {
+		t.Fatal(err)
+	}
+
+	if g, e := fmt.Sprint(factsString(b.Timeline(t4))), "[110:Feb01-Mar01@Mar10- 120:Mar01-Jun01@Apr01- 140:Jun01-@Apr01-]"; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	if g, e := fmt.Sprint(factsString(b.Facts())), "[100:Jan01-@Jan05-Feb20 100:Jan01-Mar01@Feb20-Mar10 120:Mar01-@Feb20-Apr01 100:Jan01-Feb01@Mar10-Apr01 110:Feb01-Mar01@Mar10- 120:Mar01-Jun01@Apr01- 140:Jun01-@Apr01-]"; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Box is the Cartesian product of intervals, one per dimension, like a
+// rectangle having an x range and an y range. A box having an Empty dimension
+// is empty. The dimensions of boxes used together must have the same number
+// and the same concrete types.
+type Box []Interface
+
+// String implements fmt.Stringer.
+func (x Box) String() string {
+	a := make([]string, len(x))
+	for i, v := range x {
+		a[i] = fmt.Sprint(v)
+	}
+	return strings.Join(a, " × ")
+}
+
+func (x Box) check(y Box) {
+	if len(x) != len(y) {
+		panic(fmt.Errorf("interval: mismatched box dimensions %v and %v", len(x), len(y)))
+	}
+}
+
+// Clone returns a deep copy of x.
+func (x Box) Clone() Box {
+	r := make(Box, len(x))
+	for i, v := range x {
+		r[i] = v.Clone()
+	}
+	return r
+}
+
+// IsEmpty reports whether x has no values.
+func (x Box) IsEmpty() bool {
+	for _, v := range x {
+		if v.Class() == Empty {
+			return true
+		}
+	}
+	return false
+}
+
+// Intersection returns the common values of x and y.
+func (x Box) Intersection(y Box) Box {
+	x.check(y)
+	r := make(Box, len(x))
+	for i, v := range x {
+		r[i] = Intersection(v, y[i])
+	}
+	return r
+}
+
+// Overlaps reports whether x and y have any common value.
+func (x Box) Overlaps(y Box) bool {
+	x.check(y)
+	for i, v := range x {
+		if Intersection(v, y[i]).Class() == Empty {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains reports whether all values of y are in x. An empty y is contained
+// in any box.
+func (x Box) Contains(y Box) bool {
+	x.check(y)
+	if y.IsEmpty() {
+		return true
+	}
+
+	for i, v := range x {
+		if v.Class() == Empty || compareEdges(lower(v), lower(y[i])) > 0 || compareEdges(upper(v), upper(y[i])) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Hull returns the smallest box containing x and y. Empty boxes do not
+// contribute to the hull.
+func (x Box) Hull(y Box) Box {
+	x.check(y)
+	switch {
+	case x.IsEmpty():
+		return y.Clone()
+	case y.IsEmpty():
+		return x.Clone()
+	}
+
+	r := make(Box, len(x))
+	for i, v := range x {
+		r[i] = Hull(v, y[i])
+	}
+	return r
+}
+
+// numBounds returns the bounds of the non empty interval (c, a, b) as
+// float64, using -Inf and +Inf for the missing ones.
+func numBounds[B Number](c Class, a, b B) (float64, float64, bool) {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if hasA(c) {
+		lo = float64(a)
+	}
+	if hasB(c) {
+		hi = float64(b)
+	}
+	if c == Degenerate {
+		hi = lo
+	}
+	return lo, hi, true
+}
+
+func bigIntFloat(v *big.Int) (f float64) {
+	if v != nil {
+		f, _ = v.Float64()
+	}
+	return f
+}
+
+func bigRatFloat(v *big.Rat) (f float64) {
+	if v != nil {
+		f, _ = v.Float64()
+	}
+	return f
+}
+
+// floatBounds returns the bounds of the non empty numeric interval x as
+// float64, using -Inf and +Inf for the missing ones. The result is false if x
+// is not numeric.
+func floatBounds(x Interface) (a, b float64, ok bool) {
+	switch x := x.(type) {
+	case *Float32:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Float64:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Int8:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Int16:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Int32:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Int64:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Int:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Byte:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Uint16:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Uint32:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Uint64:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Uint:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Duration:
+		return numBounds(x.Cls, x.A, x.B)
+	case *Int128:
+		return numBounds(x.Cls, bigIntFloat(x.A.BigInt()), bigIntFloat(x.B.BigInt()))
+	case *BigInt:
+		return numBounds(x.Cls, bigIntFloat(x.A), bigIntFloat(x.B))
+	case *BigRat:
+		return numBounds(x.Cls, bigRatFloat(x.A), bigRatFloat(x.B))
+	}
+	return 0, 0, false
+}
+
+// measure returns the length of the numeric interval x. The result is false
+// if x is not numeric.
+func measure(x Interface) (float64, bool) {
+	a, b, ok := floatBounds(x)
+	if x.Class() == Empty {
+		return 0, ok
+	}
+
+	return b - a, ok
+}
+
+// Volume returns the product of the lengths b-a of the dimensions of x. It is
+// zero if any dimension has a zero length, like the Empty and Degenerate
+// ones, and +Inf otherwise if any dimension is unbounded. Open and closed ends
+// do not affect the volume. The result is false if any dimension of x is not
+// of a numeric type. Duration dimensions are measured in nanoseconds.
+func (x Box) Volume() (float64, bool) {
+	r := 1.0
+	var zero bool
+	for _, v := range x {
+		n, ok := measure(v)
+		if !ok {
+			return 0, false
+		}
+
+		zero = zero || n == 0
+		r *= n
+	}
+	if zero {
+		r = 0
+	}
+	return r, true
+}
+
+// Difference returns the values of x not in y as at most 2*len(x) disjoint,
+// non empty boxes.
+func (x Box) Difference(y Box) []Box {
+	x.check(y)
+	if x.IsEmpty() {
+		return nil
+	}
+
+	if !x.Overlaps(y) {
+		return []Box{x.Clone()}
+	}
+
+	var r []Box
+	rest := x.Clone()
+	for i, v := range x {
+		for _, p := range Difference(v, y[i]) {
+			b := rest.Clone()
+			b[i] = p
+			r = append(r, b)
+		}
+		rest[i] = Intersection(v, y[i])
+	}
+	return r
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/cznic/mathutil"
+)
+
+func randBox(rng *rand.Rand) Box {
+	return Box{randInterval(rng), randInterval(rng)}
+}
+
+// boxHas reports whether the point (m, n) is in x.
+func boxHas(x Box, m, n int) bool { return x[0].(*interval).has(m) && x[1].(*interval).has(n) }
+
+func TestBox(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 2000; i++ {
+		x, y := randBox(rng), randBox(rng)
+		z := x.Intersection(y)
+		h := x.Hull(y)
+		d := x.Difference(y)
+		if len(d) > 4 {
+			t.Fatalf("%v - %v: too many boxes: %v", x, y, d)
+		}
+
+		contains, overlaps := true, false
+		for m := setMin; m <= setMax; m += 2 {
+			for n := setMin; n <= setMax; n += 2 {
+				inX, inY := boxHas(x, m, n), boxHas(y, m, n)
+				if g, e := boxHas(z, m, n), inX && inY; g != e {
+					t.Fatalf("%v ∩ %v = %v: (%v, %v): got %v, expected %v", x, y, z, m, n, g, e)
+				}
+
+				if (inX || inY) && !boxHas(h, m, n) {
+					t.Fatalf("hull %v of %v and %v: (%v, %v) missing", h, x, y, m, n)
+				}
+
+				var k int
+				for _, b := range d {
+					if boxHas(b, m, n) {
+						k++
+					}
+				}
+				if g, e := k == 1, inX && !inY; g != e || k > 1 {
+					t.Fatalf("%v - %v = %v: (%v, %v): in %v boxes", x, y, d, m, n, k)
+				}
+
+				contains = contains && (!inY || inX)
+				overlaps = overlaps || inX && inY
+			}
+		}
+		for _, b := range d {
+			if b.IsEmpty() {
+				t.Fatalf("%v - %v = %v: empty box", x, y, d)
+			}
+		}
+		if g, e := x.Overlaps(y), overlaps; g != e {
+			t.Fatalf("%v %v: overlaps got %v, expected %v", x, y, g, e)
+		}
+
+		// The interval bounds are multiples of 10, so even points
+		// distinguish containment, except for open ends of Degenerate
+		// like intervals, which randInterval does not produce.
+		if g, e := x.Contains(y), contains; g != e {
+			t.Fatalf("%v %v: contains got %v, expected %v", x, y, g, e)
+		}
+	}
+
+	for _, test := range []struct {
+		x  Box
+		e  float64
+		ok bool
+	}{
+		{Box{&Float64{Closed, 1, 3}, &Int64{LeftOpen, -2, 2}}, 8, true},
+		{Box{&Float64{Closed, 1, 3}, &Int64{Empty, 0, 0}}, 0, true},
+		{Box{&Float64{Unbounded, 0, 0}, &Int64{Empty, 0, 0}}, 0, true},
+		{Box{&Float64{Unbounded, 0, 0}, &Int64{Degenerate, 0, 0}}, 0, true},
+		{Box{&Float64{LeftBoundedOpen, 0, 0}, &Uint{Closed, 1, 2}}, math.Inf(1), true},
+		{Box{&Int128{Closed, mathutil.Int128{Lo: -2, Hi: -1}, mathutil.Int128{Hi: 1}}}, math.Exp2(64), true},
+		{Box{&Int128{RightBoundedOpen, mathutil.Int128{}, mathutil.Int128{}}, &Int{Closed, 1, 2}}, math.Inf(1), true},
+		{Box{&String{Closed, "a", "b"}}, 0, false},
+	} {
+		g, ok := test.x.Volume()
+		if g != test.e || ok != test.ok {
+			t.Fatalf("%v: got %v %v, expected %v %v", test.x, g, ok, test.e, test.ok)
+		}
+	}
+}
+
+func ExampleBox() {
+	x := Box{&Int64{LeftClosed, 0, 10}, &Int64{LeftClosed, 0, 10}}
+	y := Box{&Int64{LeftClosed, 5, 15}, &Int64{LeftClosed, 2, 4}}
+	fmt.Println(x.Intersection(y))
+	fmt.Println(x.Hull(y))
+	for _, b := range x.Difference(y) {
+		fmt.Println(b)
+	}
+	// Output:
+	// [5, 10) × [2, 4)
+	// [0, 15) × [0, 10)
+	// [0, 5) × [0, 10)
+	// [5, 10) × [0, 2)
+	// [5, 10) × [4, 10)
+}
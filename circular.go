@@ -0,0 +1,324 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// linear is an ordinary interval having Number bounds, used for the pieces of
+// arcs.
+type linear[B Number] struct {
+	Cls  Class
+	A, B B
+}
+
+func cmpNumber[B Number](x, y B) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String implements fmt.Stringer.
+func (i *linear[B]) String() string { return str(i.Cls, i.A, i.B) }
+
+// Class implements Interface.
+func (i *linear[B]) Class() Class { return i.Cls }
+
+// SetClass implements Interface.
+func (i *linear[B]) SetClass(c Class) { i.Cls = c }
+
+// Clone implements Interface.
+func (i *linear[B]) Clone() Interface { j := *i; return &j }
+
+// CompareAA implements Interface.
+func (i *linear[B]) CompareAA(other Interface) int { return cmpNumber(i.A, as[*linear[B]](i, other).A) }
+
+// CompareAB implements Interface.
+func (i *linear[B]) CompareAB(other Interface) int { return cmpNumber(i.A, as[*linear[B]](i, other).B) }
+
+// CompareBB implements Interface.
+func (i *linear[B]) CompareBB(other Interface) int { return cmpNumber(i.B, as[*linear[B]](i, other).B) }
+
+// SetAB implements Interface.
+func (i *linear[B]) SetAB() { i.A = i.B }
+
+// SetB implements Interface.
+func (i *linear[B]) SetB(other Interface) { i.B = as[*linear[B]](i, other).B }
+
+// SetBA implements Interface.
+func (i *linear[B]) SetBA(other Interface) { i.B = as[*linear[B]](i, other).A }
+
+// Arc is an interval on a circle of the values in [0, Mod), like the times of
+// a day or angles. It goes from A in the direction of increasing values to B,
+// wrapping past Mod to 0 if A >= B, so a night shift is
+//
+//	&Arc[time.Duration]{24*time.Hour, LeftClosed, 22*time.Hour, 6*time.Hour}
+//
+// and A == B makes a full turn. The class tells which of the bounds belong to
+// the arc like for ordinary intervals. Unbounded is the full circle. Half
+// bounded classes are not valid. Use NewArc to create valid arcs.
+type Arc[B Number] struct {
+	Mod  B
+	Cls  Class
+	A, B B
+}
+
+// NewArc returns a new arc on the circle of the values in [0, mod) of class c
+// having bounds a and b, where the class has them. Bounds not used by c are
+// ignored. Half bounded classes are reported as ErrClass, bounds not in [0,
+// mod) as ErrRange and NaN bounds as ErrNaN, wrapped in an *InvalidError.
+// NewArc panics if mod is not positive.
+func NewArc[B Number](mod B, c Class, a, b B) (*Arc[B], error) {
+	if !(mod > 0) {
+		panic(fmt.Errorf("interval: NewArc: invalid modulus %v", mod))
+	}
+
+	switch c {
+	case Empty, Unbounded:
+		return &Arc[B]{Mod: mod, Cls: c}, nil
+	case Degenerate:
+		b = a
+	case Open, Closed, LeftOpen, LeftClosed:
+		// ok
+	default:
+		return nil, &InvalidError{Class: c, Err: ErrClass}
+	}
+
+	for _, v := range []struct {
+		name string
+		v    B
+	}{{"a", a}, {"b", b}} {
+		switch {
+		case v.v != v.v:
+			return nil, &InvalidError{Class: c, Bound: v.name, Err: ErrNaN}
+		case v.v < 0 || v.v >= mod:
+			return nil, &InvalidError{Class: c, Bound: v.name, Err: ErrRange}
+		}
+	}
+	return &Arc[B]{mod, c, a, b}, nil
+}
+
+// TimeOfDay returns a new arc on the circle of the times of a day, represented
+// by the time elapsed since midnight. See NewArc for details.
+func TimeOfDay(c Class, a, b time.Duration) (*Arc[time.Duration], error) {
+	return NewArc(24*time.Hour, c, a, b)
+}
+
+// Angle returns a new arc on the circle of the angles in degrees. See NewArc
+// for details.
+func Angle(c Class, a, b float64) (*Arc[float64], error) { return NewArc(360, c, a, b) }
+
+// String implements fmt.Stringer.
+func (x *Arc[B]) String() string { return str(x.Cls, x.A, x.B) }
+
+// norm returns v reduced to [0, x.Mod).
+func (x *Arc[B]) norm(v B) B {
+	var r B
+	switch {
+	case isFloat[B]():
+		r = B(math.Mod(float64(v), float64(x.Mod)))
+	default:
+		r = v - v/x.Mod*x.Mod
+	}
+	if r < 0 {
+		r += x.Mod
+	}
+	return r
+}
+
+// pieces returns the ordinary intervals within [0, x.Mod) covered by x.
+func (x *Arc[B]) pieces() []Interface {
+	switch {
+	case x.Cls == Empty:
+		return nil
+	case x.Cls == Unbounded:
+		return []Interface{&linear[B]{LeftClosed, 0, x.Mod}}
+	case x.Cls == Degenerate:
+		return []Interface{&linear[B]{Degenerate, x.A, x.A}}
+	case x.A < x.B:
+		return []Interface{&linear[B]{x.Cls, x.A, x.B}}
+	}
+
+	r := []Interface{&linear[B]{LeftClosed, x.A, x.Mod}}
+	if x.Cls == Open || x.Cls == LeftOpen {
+		r[0].SetClass(Open)
+	}
+	switch {
+	case x.Cls == Closed || x.Cls == LeftOpen:
+		r = append(r, &linear[B]{Closed, 0, x.B})
+		if x.B == 0 {
+			r[1].SetClass(Degenerate)
+		}
+	case x.B != 0:
+		r = append(r, &linear[B]{LeftClosed, 0, x.B})
+	}
+	return r
+}
+
+// set returns the pieces of x as a Set.
+func (x *Arc[B]) set() (s Set) {
+	for _, p := range x.pieces() {
+		s = s.Add(p)
+	}
+	return s
+}
+
+// arcClass returns the class of an arc having the edges lo and hi.
+func arcClass(lo, hi edge) Class {
+	switch {
+	case !lo.after && hi.after:
+		return Closed
+	case !lo.after:
+		return LeftClosed
+	case hi.after:
+		return LeftOpen
+	default:
+		return Open
+	}
+}
+
+// arcs returns the arcs on the circle of x covering the values of s, in
+// ascending order of their A bounds.
+func (x *Arc[B]) arcs(s Set) (r []*Arc[B]) {
+	var v []*linear[B]
+	for p := range s.All() {
+		v = append(v, p.(*linear[B]))
+	}
+	var wrap *Arc[B]
+	if n := len(v); n > 1 && v[0].A == 0 && !lower(v[0]).after && v[n-1].B == x.Mod {
+		first, last := v[0], v[n-1]
+		wrap = &Arc[B]{x.Mod, arcClass(lower(last), upper(first)), last.A, first.B}
+		if first.Cls == Degenerate {
+			wrap.B = 0
+		}
+		v = v[1 : n-1]
+	}
+	for _, p := range v {
+		a := &Arc[B]{x.Mod, p.Cls, p.A, p.B}
+		switch {
+		case p.Cls == Degenerate:
+			a.B = a.A
+		case p.B == x.Mod && p.A == 0 && p.Cls == LeftClosed:
+			a = &Arc[B]{Mod: x.Mod, Cls: Unbounded}
+		case p.B == x.Mod:
+			a.B = 0
+		}
+		r = append(r, a)
+	}
+	if wrap != nil {
+		r = append(r, wrap)
+	}
+	return r
+}
+
+func (x *Arc[B]) check(y *Arc[B]) {
+	if x.Mod != y.Mod {
+		panic(fmt.Errorf("interval: mismatched arc moduli %v and %v", x.Mod, y.Mod))
+	}
+}
+
+// Contains reports whether v, reduced to [0, Mod), is in x.
+func (x *Arc[B]) Contains(v B) bool {
+	v = x.norm(v)
+	return x.set().Contains(&linear[B]{Degenerate, v, v})
+}
+
+// Intersection returns the common values of x and y as up to two disjoint arcs
+// in ascending order of their A bounds. For example the intersection of the
+// arcs [22:00, 06:00) and [04:00, 23:00) is [04:00, 06:00) and [22:00,
+// 23:00). Intersection panics if x and y have different moduli.
+func (x *Arc[B]) Intersection(y *Arc[B]) []*Arc[B] {
+	x.check(y)
+	var s Set
+	for _, p := range x.pieces() {
+		for _, q := range y.pieces() {
+			s = s.Add(Intersection(p, q))
+		}
+	}
+	return x.arcs(s)
+}
+
+// Union returns the values of x and y as up to two disjoint arcs in ascending
+// order of their A bounds. Union panics if x and y have different moduli.
+func (x *Arc[B]) Union(y *Arc[B]) []*Arc[B] {
+	x.check(y)
+	s := x.set()
+	for _, p := range y.pieces() {
+		s = s.Add(p)
+	}
+	return x.arcs(s)
+}
+
+// Complement returns the arc of the values of the circle not in x.
+func (x *Arc[B]) Complement() *Arc[B] {
+	s := Set{}.Add(&linear[B]{LeftClosed, 0, x.Mod})
+	for _, p := range x.pieces() {
+		s = s.Remove(p)
+	}
+	if r := x.arcs(s); len(r) != 0 {
+		return r[0]
+	}
+
+	return &Arc[B]{Mod: x.Mod, Cls: Empty}
+}
+
+// Unwrap returns the ordinary interval of type T starting at x.A and going up
+// to x.B, or to x.B+Mod if x wraps. For example the angles [350, 10] become
+// [350, 370]. The full circle becomes [0, Mod). If a bound overflows,
+// ErrOverflow is returned.
+func Unwrap[T Concrete[B], B Number, P Pointer[T]](x *Arc[B]) (*T, error) {
+	switch {
+	case x.Cls == Empty:
+		return EmptyOf[T, B, P](), nil
+	case x.Cls == Unbounded:
+		return New[T, B, P](LeftClosed, 0, x.Mod)
+	case x.Cls == Degenerate || x.A < x.B:
+		return New[T, B, P](x.Cls, x.A, x.B)
+	}
+
+	b, ok := add(x.B, x.Mod)
+	if !ok {
+		return nil, ErrOverflow
+	}
+
+	return New[T, B, P](x.Cls, x.A, b)
+}
+
+// TimeOn returns the Time interval of the times of day x on the day d in loc,
+// starting on d and ending on the next day if x wraps past midnight. The full
+// circle becomes the whole day d. Times of day are wall clock times resolved
+// as by time.Date, so the result has the expected wall clock bounds across DST
+// transitions. If they make the bounds cross, the result is Empty.
+func TimeOn(x *Arc[time.Duration], d CivilDate, loc *time.Location) *Time {
+	at := func(v time.Duration) time.Time {
+		return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, int(v), loc)
+	}
+	switch {
+	case x.Cls == Empty:
+		return &Time{Cls: Empty}
+	case x.Cls == Unbounded:
+		return &Time{LeftClosed, at(0), at(24 * time.Hour)}
+	case x.Cls == Degenerate:
+		return &Time{Degenerate, at(x.A), at(x.A)}
+	}
+
+	b := x.B
+	if x.A >= x.B {
+		b += x.Mod
+	}
+	r := &Time{x.Cls, at(x.A), at(b)}
+	if !r.A.Before(r.B) {
+		r.Cls = Empty
+	}
+	return r
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+var arcClasses = []Class{Empty, Unbounded, Degenerate, Open, Closed, LeftOpen, LeftClosed}
+
+func randArc(rng *rand.Rand) *Arc[float64] {
+	return Must(NewArc(12, arcClasses[rng.Intn(len(arcClasses))], float64(rng.Intn(12)), float64(rng.Intn(12))))
+}
+
+// arcValues returns the values k/2, k in [0, 24), in x as a string.
+func arcValues(x ...*Arc[float64]) string {
+	var b []byte
+	for k := 0; k < 24; k++ {
+		c := byte('.')
+		for _, x := range x {
+			if x.Contains(float64(k) / 2) {
+				c = 'x'
+			}
+		}
+		b = append(b, c)
+	}
+	return string(b)
+}
+
+func TestArc(t *testing.T) {
+	for _, test := range []struct {
+		x *Arc[float64]
+		e string
+	}{
+		{&Arc[float64]{12, Empty, 0, 0}, "........................"},
+		{&Arc[float64]{12, Unbounded, 0, 0}, "xxxxxxxxxxxxxxxxxxxxxxxx"},
+		{&Arc[float64]{12, Degenerate, 11, 11}, "......................x."},
+		{&Arc[float64]{12, LeftClosed, 10, 2}, "xxxx................xxxx"},
+		{&Arc[float64]{12, LeftOpen, 10, 0}, "x....................xxx"},
+		{&Arc[float64]{12, Open, 3, 3}, "xxxxxx.xxxxxxxxxxxxxxxxx"},
+		{&Arc[float64]{12, Closed, 3, 3}, "xxxxxxxxxxxxxxxxxxxxxxxx"},
+	} {
+		if g, e := arcValues(test.x), test.e; g != e {
+			t.Fatalf("%v: got %v, expected %v", test.x, g, e)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 10000; i++ {
+		x, y := randArc(rng), randArc(rng)
+		vx, vy := arcValues(x), arcValues(y)
+		var and, or, not []byte
+		for k := range vx {
+			and = append(and, '.')
+			or = append(or, '.')
+			not = append(not, 'x')
+			if vx[k] == 'x' && vy[k] == 'x' {
+				and[k] = 'x'
+			}
+			if vx[k] == 'x' || vy[k] == 'x' {
+				or[k] = 'x'
+			}
+			if vx[k] == 'x' {
+				not[k] = '.'
+			}
+		}
+		z := x.Intersection(y)
+		if g, e := arcValues(z...), string(and); g != e || len(z) > 2 {
+			t.Fatalf("%v ∩ %v = %v: got %v, expected %v", x, y, z, g, e)
+		}
+
+		z = x.Union(y)
+		if g, e := arcValues(z...), string(or); g != e || len(z) > 2 {
+			t.Fatalf("%v ∪ %v = %v: got %v, expected %v", x, y, z, g, e)
+		}
+
+		c := x.Complement()
+		if g, e := arcValues(c), string(not); g != e {
+			t.Fatalf("complement of %v = %v: got %v, expected %v", x, c, g, e)
+		}
+
+		for _, z := range append(z, c) {
+			if _, err := NewArc(z.Mod, z.Cls, z.A, z.B); err != nil {
+				t.Fatalf("%v: %v", z, err)
+			}
+		}
+	}
+
+	if _, err := NewArc(12, LeftBoundedOpen, 1, 2); !errors.Is(err, ErrClass) {
+		t.Fatalf("got %v, expected %v", err, ErrClass)
+	}
+
+	if _, err := Angle(Closed, 350, 360); !errors.Is(err, ErrRange) {
+		t.Fatalf("got %v, expected %v", err, ErrRange)
+	}
+
+	a := Must(NewArc[uint8](200, Closed, 150, 100))
+	if !a.Contains(180) || !a.Contains(250) || a.Contains(120) {
+		t.Fatal("Contains")
+	}
+
+	if _, err := Unwrap[Byte](a); err != ErrOverflow {
+		t.Fatalf("got %v, expected %v", err, ErrOverflow)
+	}
+
+	if g, err := Unwrap[Float64](Must(Angle(Closed, 350, 10))); err != nil || g.String() != "[350, 370]" {
+		t.Fatalf("got %v %v", g, err)
+	}
+
+	prague, err := time.LoadLocation("Europe/Prague")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	shift := Must(TimeOfDay(LeftClosed, 22*time.Hour, 6*time.Hour))
+	z := TimeOn(shift, CivilDate{2024, time.March, 30}, prague)
+	if g, e := z.B.Sub(z.A), 7*time.Hour; g != e || z.A.Hour() != 22 || z.B.Hour() != 6 {
+		t.Fatalf("%v: got %v, expected %v", z, g, e)
+	}
+}
+
+func ExampleArc() {
+	shift := Must(TimeOfDay(LeftClosed, 22*time.Hour, 6*time.Hour))
+	fmt.Println(shift.Contains(23*time.Hour), shift.Contains(12*time.Hour))
+	fmt.Println(shift.Intersection(Must(TimeOfDay(LeftClosed, 4*time.Hour, 23*time.Hour))))
+	fmt.Println(shift.Complement())
+	fmt.Println(Must(Angle(Closed, 350, 10)).Union(Must(Angle(Closed, 5, 20))))
+	// Output:
+	// true false
+	// [[4h0m0s, 6h0m0s) [22h0m0s, 23h0m0s)]
+	// [6h0m0s, 22h0m0s)
+	// [[350, 20]]
+}
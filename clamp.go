@@ -0,0 +1,377 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/cznic/mathutil"
+)
+
+// clamp returns the value of the interval (c, a, b) nearest to v. Successors
+// of a and predecessors of b, as returned by next and prev, are used for open
+// ends. The result is false if the interval contains no value.
+func clamp[B any](c Class, a, b, v B, less func(x, y B) bool, next, prev func(B) (B, bool)) (B, bool) {
+	var lo, hi B
+	var hasLo, hasHi bool
+	switch c {
+	case Unbounded:
+		return v, true
+	case Degenerate:
+		return a, true
+	case Open, LeftOpen, LeftBoundedOpen:
+		if lo, hasLo = next(a); !hasLo {
+			return v, false
+		}
+	case Closed, LeftClosed, LeftBoundedClosed:
+		lo, hasLo = a, true
+	case RightBoundedOpen, RightBoundedClosed:
+		// nop
+	default:
+		return v, false
+	}
+	switch c {
+	case Open, LeftClosed, RightBoundedOpen:
+		if hi, hasHi = prev(b); !hasHi {
+			return v, false
+		}
+	case Closed, LeftOpen, RightBoundedClosed:
+		hi, hasHi = b, true
+	}
+	if hasLo && hasHi && less(hi, lo) {
+		return v, false
+	}
+
+	if hasLo && less(v, lo) {
+		return lo, true
+	}
+
+	if hasHi && less(hi, v) {
+		return hi, true
+	}
+
+	return v, true
+}
+
+func less[B Number](x, y B) bool { return x < y }
+
+func succ[B Number](x B) (B, bool) { return add(x, 1) }
+
+func pred[B Number](x B) (B, bool) { return sub(x, 1) }
+
+func succFloat32(x float32) (float32, bool) {
+	r := math.Nextafter32(x, float32(math.Inf(1)))
+	return r, r > x
+}
+
+func predFloat32(x float32) (float32, bool) {
+	r := math.Nextafter32(x, float32(math.Inf(-1)))
+	return r, r < x
+}
+
+func succFloat64(x float64) (float64, bool) {
+	r := math.Nextafter(x, math.Inf(1))
+	return r, r > x
+}
+
+func predFloat64(x float64) (float64, bool) {
+	r := math.Nextafter(x, math.Inf(-1))
+	return r, r < x
+}
+
+func lessTime(x, y time.Time) bool { return x.Before(y) }
+
+func succTime(x time.Time) (time.Time, bool) { return x.Add(1), true }
+
+func predTime(x time.Time) (time.Time, bool) { return x.Add(-1), true }
+
+// udiff returns |x-y| as an U, which must be wide enough to hold it.
+func udiff[B, U Number](x, y B) U {
+	if x < y {
+		x, y = y, x
+	}
+	return U(x) - U(y)
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest float32 inside i. The result is
+// false if i contains no float32 value.
+func (i *Float32) Clamp(v float32) (float32, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[float32], succFloat32, predFloat32)
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no float32 value.
+func (i *Float32) Distance(v float32) (float32, bool) {
+	c, ok := i.Clamp(v)
+	return float32(math.Abs(float64(v) - float64(c))), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest float64 inside i. The result is
+// false if i contains no float64 value.
+func (i *Float64) Clamp(v float64) (float64, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[float64], succFloat64, predFloat64)
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no float64 value.
+func (i *Float64) Distance(v float64) (float64, bool) {
+	c, ok := i.Clamp(v)
+	return math.Abs(v - c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest int8 inside i. The result is
+// false if i contains no int8 value.
+func (i *Int8) Clamp(v int8) (int8, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[int8], succ[int8], pred[int8])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no int8 value.
+func (i *Int8) Distance(v int8) (uint8, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[int8, uint8](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest int16 inside i. The result is
+// false if i contains no int16 value.
+func (i *Int16) Clamp(v int16) (int16, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[int16], succ[int16], pred[int16])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no int16 value.
+func (i *Int16) Distance(v int16) (uint16, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[int16, uint16](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest int32 inside i. The result is
+// false if i contains no int32 value.
+func (i *Int32) Clamp(v int32) (int32, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[int32], succ[int32], pred[int32])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no int32 value.
+func (i *Int32) Distance(v int32) (uint32, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[int32, uint32](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest int64 inside i. The result is
+// false if i contains no int64 value.
+func (i *Int64) Clamp(v int64) (int64, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[int64], succ[int64], pred[int64])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no int64 value.
+func (i *Int64) Distance(v int64) (uint64, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[int64, uint64](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest int inside i. The result is
+// false if i contains no int value.
+func (i *Int) Clamp(v int) (int, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[int], succ[int], pred[int])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no int value.
+func (i *Int) Distance(v int) (uint, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[int, uint](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest byte inside i. The result is
+// false if i contains no byte value.
+func (i *Byte) Clamp(v byte) (byte, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[byte], succ[byte], pred[byte])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no byte value.
+func (i *Byte) Distance(v byte) (byte, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[byte, byte](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest uint16 inside i. The result is
+// false if i contains no uint16 value.
+func (i *Uint16) Clamp(v uint16) (uint16, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[uint16], succ[uint16], pred[uint16])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no uint16 value.
+func (i *Uint16) Distance(v uint16) (uint16, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[uint16, uint16](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest uint32 inside i. The result is
+// false if i contains no uint32 value.
+func (i *Uint32) Clamp(v uint32) (uint32, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[uint32], succ[uint32], pred[uint32])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no uint32 value.
+func (i *Uint32) Distance(v uint32) (uint32, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[uint32, uint32](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest uint64 inside i. The result is
+// false if i contains no uint64 value.
+func (i *Uint64) Clamp(v uint64) (uint64, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[uint64], succ[uint64], pred[uint64])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no uint64 value.
+func (i *Uint64) Distance(v uint64) (uint64, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[uint64, uint64](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest uint inside i. The result is
+// false if i contains no uint value.
+func (i *Uint) Clamp(v uint) (uint, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[uint], succ[uint], pred[uint])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no uint value.
+func (i *Uint) Distance(v uint) (uint, bool) {
+	c, ok := i.Clamp(v)
+	return udiff[uint, uint](v, c), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest time.Duration inside i. The
+// result is false if i contains no time.Duration value.
+func (i *Duration) Clamp(v time.Duration) (time.Duration, bool) {
+	return clamp(i.Cls, i.A, i.B, v, less[time.Duration], succ[time.Duration], pred[time.Duration])
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. Distances not representable by time.Duration are saturated to
+// math.MaxInt64. The result is false if i contains no time.Duration value.
+func (i *Duration) Distance(v time.Duration) (time.Duration, bool) {
+	c, ok := i.Clamp(v)
+	d := udiff[time.Duration, uint64](v, c)
+	if d > math.MaxInt64 {
+		d = math.MaxInt64
+	}
+	return time.Duration(d), ok
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the time one nanosecond inside i. The
+// result is false if i contains no time.
+func (i *Time) Clamp(v time.Time) (time.Time, bool) {
+	return clamp(i.Cls, i.A, i.B, v, lessTime, succTime, predTime)
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. Distances are saturated like by time.Time.Sub. The result is
+// false if i contains no time.
+func (i *Time) Distance(v time.Time) (time.Duration, bool) {
+	c, ok := i.Clamp(v)
+	if v.Before(c) {
+		return c.Sub(v), ok
+	}
+
+	return v.Sub(c), ok
+}
+
+func lessDate(x, y CivilDate) bool { return x.Compare(y) < 0 }
+
+func succDate(x CivilDate) (CivilDate, bool) { return x.AddDays(1), true }
+
+func predDate(x CivilDate) (CivilDate, bool) { return x.AddDays(-1), true }
+
+// Clamp returns the date of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest day inside i. The result is
+// false if i contains no day.
+func (i *Date) Clamp(v CivilDate) (CivilDate, bool) {
+	return clamp(i.Cls, i.A, i.B, v, lessDate, succDate, predDate)
+}
+
+// Distance returns the number of days between v and i.Clamp(v), which is zero
+// if i contains v. The result is false if i contains no day.
+func (i *Date) Distance(v CivilDate) (int, bool) {
+	c, ok := i.Clamp(v)
+	if d := v.DaysSince(c); d > 0 {
+		return d, ok
+	}
+
+	return c.DaysSince(v), ok
+}
+
+func lessInt128(x, y mathutil.Int128) bool { return x.Cmp(y) < 0 }
+
+func succInt128(x mathutil.Int128) (mathutil.Int128, bool) {
+	r, cy := x.Add(mathutil.Int128{Lo: 1})
+	return r, !cy
+}
+
+func predInt128(x mathutil.Int128) (mathutil.Int128, bool) {
+	r, cy := x.Add(mathutil.Int128{Lo: -1, Hi: -1})
+	return r, !cy
+}
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest Int128 inside i. The result is
+// false if i contains no Int128 value.
+func (i *Int128) Clamp(v mathutil.Int128) (mathutil.Int128, bool) {
+	return clamp(i.Cls, i.A, i.B, v, lessInt128, succInt128, predInt128)
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no Int128 value.
+func (i *Int128) Distance(v mathutil.Int128) (*big.Int, bool) {
+	c, ok := i.Clamp(v)
+	d := v.BigInt()
+	return d.Abs(d.Sub(d, c.BigInt())), ok
+}
+
+func lessBigInt(x, y *big.Int) bool { return x.Cmp(y) < 0 }
+
+func succBigInt(x *big.Int) (*big.Int, bool) { return big.NewInt(0).Add(x, big.NewInt(1)), true }
+
+func predBigInt(x *big.Int) (*big.Int, bool) { return big.NewInt(0).Sub(x, big.NewInt(1)), true }
+
+// Clamp returns the value of i nearest to v, that is v itself if i contains v.
+// Open ends of i are represented by the nearest integer inside i. The result
+// is false if i contains no integer. The result never shares its value with i
+// or v.
+func (i *BigInt) Clamp(v *big.Int) (*big.Int, bool) {
+	c, ok := clamp(i.Cls, i.A, i.B, v, lessBigInt, succBigInt, predBigInt)
+	return big.NewInt(0).Set(c), ok
+}
+
+// Distance returns the distance between v and i.Clamp(v), which is zero if i
+// contains v. The result is false if i contains no integer.
+func (i *BigInt) Distance(v *big.Int) (*big.Int, bool) {
+	c, ok := i.Clamp(v)
+	return c.Abs(c.Sub(v, c)), ok
+}
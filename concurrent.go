@@ -0,0 +1,94 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentSet is a Set safe for concurrent use by multiple goroutines.
+// Readers never block, they operate on the version of the set current when
+// they start. Writers are serialized and publish a new version atomically.
+//
+// All intervals used with a ConcurrentSet must have the same concrete type.
+//
+// The zero value is an empty ConcurrentSet ready to use. A ConcurrentSet must
+// not be copied after first use.
+type ConcurrentSet struct {
+	mu   sync.Mutex // Serializes writers.
+	root atomic.Pointer[setNode]
+}
+
+// Snapshot returns the current version of s. Later modifications of s do not
+// affect the result.
+func (s *ConcurrentSet) Snapshot() Set { return Set{s.root.Load()} }
+
+// update replaces the current version of s by the result of f, unless f
+// returns false.
+func (s *ConcurrentSet) update(f func(Set) (Set, bool)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := f(s.Snapshot())
+	if ok {
+		s.root.Store(t.root)
+	}
+	return ok
+}
+
+// Len returns the number of members of s.
+func (s *ConcurrentSet) Len() int { return s.Snapshot().Len() }
+
+// Add adds the values of x to s.
+func (s *ConcurrentSet) Add(x Interface) {
+	s.update(func(t Set) (Set, bool) { return t.Add(x), true })
+}
+
+// Remove removes the values of x from s.
+func (s *ConcurrentSet) Remove(x Interface) {
+	s.update(func(t Set) (Set, bool) { return t.Remove(x), true })
+}
+
+// Reserve adds the values of x to s and returns true if none of them is in s
+// already. Otherwise s is not modified and the result is false. An Empty x is
+// reserved trivially.
+func (s *ConcurrentSet) Reserve(x Interface) bool {
+	if s.Overlaps(x) {
+		return false
+	}
+
+	return s.update(func(t Set) (Set, bool) {
+		if t.Overlaps(x) {
+			return t, false
+		}
+
+		return t.Add(x), true
+	})
+}
+
+// Contains reports whether all values of x are in s. An Empty x is contained
+// in any set.
+func (s *ConcurrentSet) Contains(x Interface) bool { return s.Snapshot().Contains(x) }
+
+// Overlaps reports whether any value of x is in s.
+func (s *ConcurrentSet) Overlaps(x Interface) bool { return s.Snapshot().Overlaps(x) }
+
+// Overlapping returns an iterator over the members of s overlapping x in
+// ascending order. The iteration uses the version of s current when it starts.
+func (s *ConcurrentSet) Overlapping(x Interface) iter.Seq[Interface] {
+	return func(yield func(Interface) bool) {
+		s.Snapshot().Overlapping(x)(yield)
+	}
+}
+
+// All returns an iterator over the members of s in ascending order. The
+// iteration uses the version of s current when it starts.
+func (s *ConcurrentSet) All() iter.Seq[Interface] {
+	return func(yield func(Interface) bool) {
+		s.Snapshot().All()(yield)
+	}
+}
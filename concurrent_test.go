@@ -0,0 +1,79 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentSet is most useful when run with -race.
+func TestConcurrentSet(t *testing.T) {
+	const (
+		workers = 8
+		n       = 200
+	)
+	var s ConcurrentSet
+	var wg sync.WaitGroup
+	reserved := make([][]Interface, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < n; i++ {
+				a := int64(rng.Intn(1000))
+				x := &Int64{LeftClosed, a, a + 1 + int64(rng.Intn(5))}
+				if s.Reserve(x) {
+					reserved[w] = append(reserved[w], x)
+				}
+				for y := range s.Overlapping(x) {
+					if Intersection(x, y).Class() == Empty {
+						t.Errorf("%v %v: not overlapping", x, y)
+					}
+				}
+				s.Contains(x)
+				s.Len()
+			}
+		}(w)
+	}
+	wg.Wait()
+	var all []Interface
+	for _, v := range reserved {
+		all = append(all, v...)
+	}
+	sort.Sort(Slice(all))
+	for i := 1; i < len(all); i++ {
+		if x, y := all[i-1], all[i]; Intersection(x, y).Class() != Empty {
+			t.Fatalf("%v %v: reserved both", x, y)
+		}
+	}
+	for _, x := range all {
+		if !s.Contains(x) {
+			t.Fatalf("%v: not contained", x)
+		}
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			for _, x := range reserved[w] {
+				s.Remove(x)
+				if s.Overlaps(x) {
+					t.Errorf("%v: overlaps after removal", x)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if g := s.Len(); g != 0 {
+		t.Fatalf("got %v members, expected 0", g)
+	}
+}
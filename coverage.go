@@ -0,0 +1,69 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import "sort"
+
+// Segment is a piece of the union of some intervals covered by a constant
+// number of them.
+type Segment struct {
+	X     Interface // The piece.
+	Depth int       // Number of the intervals covering X.
+}
+
+type event struct {
+	e     edge
+	delta int
+}
+
+// Coverage returns the maximal pieces of the union of xs, each together with
+// the number of xs covering it, in ascending order. For example
+//
+//	Coverage([1, 3], [2, 4), [4, 5)) is [1, 2):1 [2, 3]:2 (3, 5):1
+//
+// Open and closed bounds having the same value are distinguished, so [1, 2]
+// and [2, 3] produce a Degenerate piece {2} of depth 2. All of xs must have
+// the same concrete type. Coverage runs in O(n log n).
+func Coverage(xs []Interface) []Segment {
+	var a []event
+	for _, x := range xs {
+		if x.Class() != Empty {
+			a = append(a, event{lower(x), 1}, event{upper(x), -1})
+		}
+	}
+	sort.SliceStable(a, func(i, j int) bool { return compareEdges(a[i].e, a[j].e) < 0 })
+	var r []Segment
+	var from edge
+	depth := 0
+	for i := 0; i < len(a); {
+		e := a[i].e
+		d := depth
+		for ; i < len(a) && compareEdges(a[i].e, e) == 0; i++ {
+			d += a[i].delta
+		}
+		if d == depth {
+			continue
+		}
+
+		if depth != 0 {
+			r = append(r, Segment{span(xs[0], from, e), depth})
+		}
+		from, depth = e, d
+	}
+	return r
+}
+
+// MaxDepth returns the maximum number of xs covering a single value and the
+// first, in ascending order, of the pieces covered by that number of xs. If
+// xs has no non empty intervals, MaxDepth returns (0, nil). All of xs must
+// have the same concrete type.
+func MaxDepth(xs []Interface) (depth int, x Interface) {
+	for _, s := range Coverage(xs) {
+		if s.Depth > depth {
+			depth, x = s.Depth, s.X
+		}
+	}
+	return depth, x
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"iter"
+	"time"
+)
+
+var _ Interface = (*Date)(nil)
+
+// CivilDate is a calendar date independent of any time zone.
+type CivilDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of t in the location of t.
+func DateOf(t time.Time) CivilDate {
+	y, m, d := t.Date()
+	return CivilDate{y, m, d}
+}
+
+func (d CivilDate) time() time.Time { return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC) }
+
+// String implements fmt.Stringer.
+func (d CivilDate) String() string { return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day) }
+
+// IsValid reports whether d is a valid date, for example February 30 is not.
+func (d CivilDate) IsValid() bool { return DateOf(d.time()) == d }
+
+// Compare returns -1, 0 or 1 when d is before, equal to or after e.
+func (d CivilDate) Compare(e CivilDate) int {
+	switch {
+	case d.Year != e.Year:
+		return cmpInt(d.Year, e.Year)
+	case d.Month != e.Month:
+		return cmpInt(int(d.Month), int(e.Month))
+	default:
+		return cmpInt(d.Day, e.Day)
+	}
+}
+
+// AddDays returns the date n days after d.
+func (d CivilDate) AddDays(n int) CivilDate {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.UTC))
+}
+
+// DaysSince returns the number of days from e to d.
+func (d CivilDate) DaysSince(e CivilDate) int {
+	// Not time.Time.Sub, which saturates at about 292 years.
+	return int((d.time().Unix() - e.time().Unix()) / (24 * 60 * 60))
+}
+
+// In returns the midnight starting d in loc. A midnight not existing in loc is
+// resolved as by time.Date.
+func (d CivilDate) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Date is an interval having CivilDate bounds.
+type Date struct {
+	Cls  Class
+	A, B CivilDate
+}
+
+// String implements fmt.Stringer.
+func (i *Date) String() string { return str(i.Cls, i.A, i.B) }
+
+// Class implements Interface.
+func (i *Date) Class() Class { return i.Cls }
+
+// SetClass implements Interface.
+func (i *Date) SetClass(c Class) { i.Cls = c }
+
+// Clone implements Interface.
+func (i *Date) Clone() Interface { j := *i; return &j }
+
+// CompareAA implements Interface.
+func (i *Date) CompareAA(other Interface) int { return i.A.Compare(as[*Date](i, other).A) }
+
+// CompareAB implements Interface.
+func (i *Date) CompareAB(other Interface) int { return i.A.Compare(as[*Date](i, other).B) }
+
+// CompareBB implements Interface.
+func (i *Date) CompareBB(other Interface) int { return i.B.Compare(as[*Date](i, other).B) }
+
+// SetAB implements Interface.
+func (i *Date) SetAB() { i.A = i.B }
+
+// SetB implements Interface.
+func (i *Date) SetB(other Interface) { i.B = as[*Date](i, other).B }
+
+// SetBA implements Interface.
+func (i *Date) SetBA(other Interface) { i.B = as[*Date](i, other).A }
+
+// Canonical returns the canonical form of i, which has the same days as i and
+// is either Empty, Unbounded, LeftClosed, LeftBoundedClosed or
+// RightBoundedOpen. For example [Jan 1, Jan 31] becomes [Jan 1, Feb 1). Two
+// Date intervals having the same days have equal canonical forms.
+func (i *Date) Canonical() *Date {
+	j := &Date{Cls: i.Cls}
+	if i.Cls == Empty || i.Cls == Unbounded {
+		return j
+	}
+
+	lo, hi := lower(i), upper(i)
+	if lo.inf == 0 {
+		j.A = i.A
+		if lo.after {
+			j.A = j.A.AddDays(1)
+		}
+	}
+	if hi.inf == 0 {
+		j.B = i.B
+		if i.Cls == Degenerate {
+			j.B = i.A
+		}
+		if hi.after {
+			j.B = j.B.AddDays(1)
+		}
+	}
+	switch {
+	case lo.inf == 0 && hi.inf == 0:
+		j.Cls = LeftClosed
+		if j.A.Compare(j.B) >= 0 {
+			j = &Date{Cls: Empty}
+		}
+	case lo.inf == 0:
+		j.Cls = LeftBoundedClosed
+	default:
+		j.Cls = RightBoundedOpen
+	}
+	return j
+}
+
+// Equal reports whether i and j have the same days.
+func (i *Date) Equal(j *Date) bool { return *i.Canonical() == *j.Canonical() }
+
+// Days returns the number of days in i. The result is false if i is
+// unbounded.
+func (i *Date) Days() (int, bool) {
+	switch j := i.Canonical(); j.Cls {
+	case Empty:
+		return 0, true
+	case LeftClosed:
+		return j.B.DaysSince(j.A), true
+	}
+	return 0, false
+}
+
+// periods returns an iterator over the intersections of i with the periods
+// starting at the dates returned by next.
+func (i *Date) periods(method string, next func(CivilDate) CivilDate) iter.Seq[*Date] {
+	j := i.Canonical()
+	if j.Cls == Unbounded || j.Cls == RightBoundedOpen {
+		panic(fmt.Errorf("interval: Date.%s: %v has no lower bound", method, i))
+	}
+
+	return func(yield func(*Date) bool) {
+		if j.Cls == Empty {
+			return
+		}
+
+		for a := j.A; j.Cls != LeftClosed || a.Compare(j.B) < 0; {
+			b := next(a)
+			if j.Cls == LeftClosed && b.Compare(j.B) > 0 {
+				b = j.B
+			}
+			if !yield(&Date{LeftClosed, a, b}) {
+				return
+			}
+
+			a = b
+		}
+	}
+}
+
+// Months returns an iterator over the intersections of i with the calendar
+// months, in canonical form and in ascending order. If i has no upper bound,
+// the iteration never ends on its own. Months panics if i is not Empty and has
+// no lower bound.
+func (i *Date) Months() iter.Seq[*Date] {
+	return i.periods("Months", func(d CivilDate) CivilDate {
+		return DateOf(time.Date(d.Year, d.Month+1, 1, 0, 0, 0, 0, time.UTC))
+	})
+}
+
+// Years returns an iterator over the intersections of i with the calendar
+// years, in canonical form and in ascending order. If i has no upper bound,
+// the iteration never ends on its own. Years panics if i is not Empty and has
+// no lower bound.
+func (i *Date) Years() iter.Seq[*Date] {
+	return i.periods("Years", func(d CivilDate) CivilDate { return CivilDate{d.Year + 1, time.January, 1} })
+}
+
+// Time returns the Time interval covering the days of i in loc, from the
+// midnight starting the first day to the midnight ending the last one.
+func (i *Date) Time(loc *time.Location) *Time {
+	j := i.Canonical()
+	r := &Time{Cls: j.Cls}
+	if hasA(j.Cls) {
+		r.A = j.A.In(loc)
+	}
+	if hasB(j.Cls) {
+		r.B = j.B.In(loc)
+	}
+	return r
+}
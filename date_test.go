@@ -0,0 +1,148 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCivilDate(t *testing.T) {
+	d := CivilDate{2024, time.February, 28}
+	if g, e := d.AddDays(1), (CivilDate{2024, time.February, 29}); g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	if g, e := d.AddDays(-59), (CivilDate{2023, time.December, 31}); g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	if g, e := (CivilDate{2025, time.March, 1}).DaysSince(d), 367; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	if g, e := (CivilDate{2500, time.January, 1}).DaysSince(CivilDate{2000, time.January, 1}), 182622; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	if g, e := (CivilDate{1500, time.January, 1}).DaysSince(CivilDate{2000, time.January, 1}), -182621; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	if !d.IsValid() || (CivilDate{2023, time.February, 29}).IsValid() || (CivilDate{2024, 13, 1}).IsValid() {
+		t.Fatal("IsValid")
+	}
+
+	if g, e := d.Compare(CivilDate{2024, time.March, 1}), -1; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	if g, e := DateOf(time.Date(2024, 1, 1, 0, 30, 0, 0, time.FixedZone("", 3600)).UTC()), (CivilDate{2023, time.December, 31}); g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+}
+
+func TestDate(t *testing.T) {
+	day := func(m time.Month, d int) CivilDate { return CivilDate{2024, m, d} }
+	for i, test := range []struct {
+		x    *Date
+		e    string
+		days int
+	}{
+		{&Date{Empty, day(1, 1), day(1, 1)}, "{}", 0},
+		{&Date{Unbounded, day(1, 1), day(1, 1)}, "(-∞, ∞)", -1},
+		{&Date{Degenerate, day(2, 29), day(1, 1)}, "[2024-02-29, 2024-03-01)", 1},
+		{&Date{Closed, day(1, 1), day(1, 31)}, "[2024-01-01, 2024-02-01)", 31},
+		{&Date{LeftClosed, day(1, 1), day(2, 1)}, "[2024-01-01, 2024-02-01)", 31},
+		{&Date{Open, day(12, 30), day(12, 31)}, "{}", 0},
+		{&Date{Open, day(12, 29), day(12, 31)}, "[2024-12-30, 2024-12-31)", 1},
+		{&Date{LeftOpen, day(12, 30), day(12, 31)}, "[2024-12-31, 2025-01-01)", 1},
+		{&Date{LeftBoundedOpen, day(1, 1), day(1, 1)}, "[2024-01-02, ∞)", -1},
+		{&Date{RightBoundedClosed, day(1, 1), day(1, 1)}, "(-∞, 2024-01-02)", -1},
+		{&Date{Closed, CivilDate{1900, 1, 1}, CivilDate{2199, 12, 31}}, "[1900-01-01, 2200-01-01)", 109573},
+	} {
+		c := test.x.Canonical()
+		if g, e := c.String(), test.e; g != e {
+			t.Fatalf("%v: %v: got %v, expected %v", i, test.x, g, e)
+		}
+
+		if !c.Equal(test.x) {
+			t.Fatalf("%v: %v: not equal to its canonical form", i, test.x)
+		}
+
+		g, ok := test.x.Days()
+		if !ok {
+			g = -1
+		}
+		if e := test.days; g != e {
+			t.Fatalf("%v: %v: got %v days, expected %v", i, test.x, g, e)
+		}
+	}
+
+	if x, err := New[Date](Closed, day(2, 30), day(3, 1)); !errors.Is(err, ErrDate) {
+		t.Fatalf("got %v %v, expected %v", x, err, ErrDate)
+	}
+
+	x := &Date{Closed, day(1, 15), day(3, 10)}
+	y := &Date{LeftClosed, day(3, 1), day(4, 1)}
+	if g, e := fmt.Sprint(Intersection(x, y)), "[2024-03-01, 2024-03-10]"; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	var g []string
+	for m := range x.Months() {
+		g = append(g, m.String())
+	}
+	if e := "[[2024-01-15, 2024-02-01) [2024-02-01, 2024-03-01) [2024-03-01, 2024-03-11)]"; fmt.Sprint(g) != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	g = nil
+	for y := range (&Date{LeftBoundedClosed, day(6, 1), day(1, 1)}).Years() {
+		g = append(g, y.String())
+		if len(g) == 2 {
+			break
+		}
+	}
+	if e := "[[2024-06-01, 2025-01-01) [2025-01-01, 2026-01-01)]"; fmt.Sprint(g) != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic")
+			}
+		}()
+
+		(&Date{RightBoundedOpen, day(1, 1), day(1, 1)}).Months()
+	}()
+
+	prague, err := time.LoadLocation("Europe/Prague")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	z := (&Date{Degenerate, day(3, 31), day(3, 31)}).Time(prague)
+	if g, e := z.B.Sub(z.A), 23*time.Hour; z.Cls != LeftClosed || g != e {
+		t.Fatalf("got %v %v, expected %v", z.Cls, g, e)
+	}
+}
+
+func ExampleDate() {
+	x := &Date{Closed, CivilDate{2024, time.January, 20}, CivilDate{2024, time.February, 10}}
+	n, _ := x.Days()
+	fmt.Println(x.Canonical(), n)
+	for m := range x.Months() {
+		n, _ := m.Days()
+		fmt.Println(m, n)
+	}
+	// Output:
+	// [2024-01-20, 2024-02-11) 22
+	// [2024-01-20, 2024-02-01) 12
+	// [2024-02-01, 2024-02-11) 10
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+// edge is a cut of the ordered set of values separating an interval from the
+// values below or above it. A finite edge lies just before or just after the
+// bound a or b of an interval. For example the lower edge of [1, 2) lies just
+// before 1 while its upper edge lies just before 2.
+type edge struct {
+	x     Interface // Owner of the bound. Nil for infinite edges.
+	b     bool      // The bound is x.B, otherwise x.A.
+	after bool      // The edge lies just after the bound, otherwise just before it.
+	inf   int       // -1 for -∞, 1 for ∞, 0 for finite edges.
+}
+
+// lower returns the lower edge of the non empty interval x.
+func lower(x Interface) edge {
+	switch x.Class() {
+	case Unbounded, RightBoundedOpen, RightBoundedClosed:
+		return edge{inf: -1}
+	case Degenerate, Closed, LeftClosed, LeftBoundedClosed:
+		return edge{x: x}
+	case Open, LeftOpen, LeftBoundedOpen:
+		return edge{x: x, after: true}
+	}
+	panic("internal error")
+}
+
+// upper returns the upper edge of the non empty interval x.
+func upper(x Interface) edge {
+	switch x.Class() {
+	case Unbounded, LeftBoundedOpen, LeftBoundedClosed:
+		return edge{inf: 1}
+	case Degenerate:
+		return edge{x: x, after: true}
+	case Closed, LeftOpen, RightBoundedClosed:
+		return edge{x: x, b: true, after: true}
+	case Open, LeftClosed, RightBoundedOpen:
+		return edge{x: x, b: true}
+	}
+	panic("internal error")
+}
+
+// compareValues compares the bound values of the finite edges e and f.
+func compareValues(e, f edge) int {
+	switch {
+	case !e.b && !f.b:
+		return e.x.CompareAA(f.x)
+	case !e.b && f.b:
+		return e.x.CompareAB(f.x)
+	case e.b && !f.b:
+		return compareBA(e.x, f.x)
+	default:
+		return e.x.CompareBB(f.x)
+	}
+}
+
+// compareEdges returns -1, 0 or 1 when e lies before, at or after f.
+func compareEdges(e, f edge) int {
+	if e.inf != 0 || f.inf != 0 {
+		return cmpInt(e.inf, f.inf)
+	}
+
+	if n := compareValues(e, f); n != 0 {
+		return n
+	}
+
+	switch {
+	case e.after == f.after:
+		return 0
+	case f.after:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func cmpInt(x, y int) int {
+	if x < y {
+		return -1
+	}
+
+	if x > y {
+		return 1
+	}
+
+	return 0
+}
+
+// edgesClass returns the class of the interval having the edges lo and hi.
+func edgesClass(lo, hi edge) Class {
+	switch {
+	case compareEdges(lo, hi) >= 0:
+		return Empty
+	case lo.inf < 0 && hi.inf > 0:
+		return Unbounded
+	case lo.inf < 0 && hi.after:
+		return RightBoundedClosed
+	case lo.inf < 0:
+		return RightBoundedOpen
+	case hi.inf > 0 && lo.after:
+		return LeftBoundedOpen
+	case hi.inf > 0:
+		return LeftBoundedClosed
+	case compareValues(lo, hi) == 0:
+		return Degenerate
+	case !lo.after && hi.after:
+		return Closed
+	case !lo.after:
+		return LeftClosed
+	case hi.after:
+		return LeftOpen
+	default:
+		return Open
+	}
+}
+
+// span returns a new interval having the edges lo and hi. If both edges are
+// infinite, the result is a clone of proto.
+func span(proto Interface, lo, hi edge) Interface {
+	switch {
+	case lo.inf == 0:
+		z := lo.x.Clone()
+		if lo.b {
+			z.SetAB()
+		}
+		return setUpper(z, edge{x: z, after: lo.after}, hi)
+	case hi.inf == 0:
+		return setUpper(hi.x.Clone(), lo, hi)
+	default:
+		return setClass(proto.Clone(), edgesClass(lo, hi))
+	}
+}
+
+// setUpper sets the upper edge of z, which must not be shared with other
+// intervals, to hi and returns z. The lower edge of z is lo.
+func setUpper(z Interface, lo, hi edge) Interface {
+	if hi.inf == 0 {
+		switch {
+		case hi.b:
+			z.SetB(hi.x)
+		default:
+			z.SetBA(hi.x)
+		}
+		hi = edge{x: z, b: true, after: hi.after}
+	}
+	z.SetClass(edgesClass(lo, hi))
+	return z
+}
@@ -140,3 +140,125 @@ func Union(x, y Interface) Interface {
 	}
 	return setClass(x.Clone(), Empty)
 }
+
+// Hull returns the convex hull of xs, that is the smallest interval containing
+// all of xs. Unlike Union, the hull of disjoint intervals is not nil. Empty
+// intervals do not contribute to the hull. The hull of no intervals is nil.
+func Hull(xs ...Interface) Interface {
+	if len(xs) == 0 {
+		return nil
+	}
+
+	r := xs[0].Clone()
+	for _, x := range xs[1:] {
+		r = hull(r, x)
+	}
+	return r
+}
+
+func hull(x, y Interface) Interface {
+	x, y, h := hash(x, y)
+	switch h {
+	case 9717, 10165, 10229, 9973, 9974, 9397, 9461, 9398, 9462:
+		return setClass(x.Clone(), Open)
+	case 15701, 15717, 15733, 10069, 10085, 10101, 9301, 9317, 9333, 9334:
+		return setClass(setB(x.Clone(), y), Open)
+	case 15797, 15861, 15541, 15605, 15542, 15606:
+		return setClass(x.Clone(), LeftOpen)
+	case 15445, 15461, 15477, 15478, 9557, 9573, 9589, 9653, 9813, 9829, 9845, 9909, 9846, 9910:
+		return setClass(setB(x.Clone(), y), LeftOpen)
+	case 21761, 21505, 21506, 16145, 16161, 16177, 15889, 15905, 15921, 15922, 10513, 10529, 10545, 10257, 10273, 10289, 10290:
+		return setClass(x.Clone(), LeftBoundedOpen)
+	case 9975, 9979, 9983, 9399, 9463, 9467, 9471:
+		return setClass(setB(y.Clone(), x), Open)
+	case 6407, 3584, 9335:
+		return setClass(y.Clone(), Open)
+	case 6923, 6927, 6411, 6415:
+		return setClass(setBA(y.Clone(), x), LeftOpen)
+	case 12727, 12791, 12795, 12799, 15543, 15607, 15611, 15615:
+		return setClass(setB(y.Clone(), x), LeftOpen)
+	case 12663, 6919, 4096, 15479, 9847, 9911:
+		return setClass(y.Clone(), LeftOpen)
+	case 13107, 7427, 4608, 21507, 18739, 15923, 10291:
+		return setClass(y.Clone(), LeftBoundedOpen)
+	case 18613, 18677, 18614, 18678:
+		return setClass(x.Clone(), LeftClosed)
+	case 12885, 12901, 12917, 12918, 7173, 7174, 6405, 6406, 18517, 18533, 18549, 18550:
+		return setClass(setB(x.Clone(), y), LeftClosed)
+	case 6146:
+		return setClass(x.Clone(), Degenerate)
+	case 12469, 12533, 12470, 12534, 12981, 13045, 12982, 13046, 12725, 12789, 12726, 12790:
+		return setClass(x.Clone(), Closed)
+	case 6145:
+		return setClass(setBA(x.Clone(), y), Closed)
+	case 12373, 12389, 12405, 12406, 12629, 12645, 12661, 12662, 6661, 6662, 6917, 6918:
+		return setClass(setB(x.Clone(), y), Closed)
+	case 13329, 13345, 13361, 13362, 13073, 13089, 13105, 13106, 7681, 7682, 7425, 7426, 24577, 24578, 18961, 18977, 18993, 18994, 18705, 18721, 18737, 18738:
+		return setClass(x.Clone(), LeftBoundedClosed)
+	case 18615, 18679, 18683, 18687, 9718, 9719, 9723, 9727, 10166, 10230, 10167, 10231, 10235, 10239:
+		return setClass(setB(y.Clone(), x), LeftClosed)
+	case 12919, 7175, 4352, 18551, 15734, 15735, 10102, 10103:
+		return setClass(y.Clone(), LeftClosed)
+	case 6667, 6671, 6147, 7179, 7183:
+		return setClass(setBA(y.Clone(), x), Closed)
+	case 12471, 12535, 12539, 12543, 12983, 13047, 13051, 13055, 15798, 15862, 15799, 15863, 15867, 15871:
+		return setClass(setB(y.Clone(), x), Closed)
+	case 3328:
+		return setClass(y.Clone(), Degenerate)
+	case 12407, 6663, 3840, 9590, 9654, 9591, 9655:
+		return setClass(y.Clone(), Closed)
+	case 13363, 7683, 4864, 24579, 21762, 21763, 18995, 16178, 16179, 10546, 10547:
+		return setClass(y.Clone(), LeftBoundedClosed)
+	case 19652, 19656, 19660, 19332, 19396, 19400, 19404, 11204, 11208, 11212, 10884, 10948, 10952, 10956, 28096, 27776, 27840:
+		return setClass(x.Clone(), RightBoundedOpen)
+	case 13636, 7940, 5120, 19268, 16452, 10820, 27712:
+		return setClass(y.Clone(), RightBoundedOpen)
+	case 8200, 8204, 7944, 7948:
+		return setClass(setBA(x.Clone(), x), RightBoundedClosed)
+	case 13956, 14020, 14024, 14028, 13700, 13764, 13768, 13772, 16772, 16836, 16840, 16844, 16516, 16580, 16584, 16588, 30848, 30912:
+		return setClass(x.Clone(), RightBoundedClosed)
+	case 13892, 8196, 5376, 19524, 19588, 16708, 11076, 11140, 30784, 27968, 28032:
+		return setClass(y.Clone(), RightBoundedClosed)
+	case 25092, 25096, 25100, 24836, 24840, 24844, 22276, 22280, 22284, 22020, 22024, 22028, 1024, 512, 256, 2048, 1792, 1536, 1280, 768, 2560, 2304, 0:
+		return setClass(x.Clone(), Unbounded)
+	}
+	return setClass(x.Clone(), Empty)
+}
+
+// Gap returns the interval lying strictly between x and y if their union is
+// not an interval. Otherwise, that is when x and y overlap or touch or any of
+// them is empty, the result is Empty. Closedness of the edges of the gap is the
+// opposite of that of the respective edges of x and y, for example the gap
+// between [1, 3) and (5, 9] is [3, 5].
+func Gap(x, y Interface) Interface {
+	x, y, h := hash(x, y)
+	switch h {
+	case 6661, 6145, 7681, 7173:
+		return setClass(setBA(x.Clone(), y), Open)
+	case 7425, 6917, 6405:
+		return setClass(setBA(x.Clone(), y), LeftOpen)
+	case 12373, 13329, 12885, 16145, 15701:
+		return setClass(setBA(setAB(x.Clone()), y), Open)
+	case 13073, 12629, 15889, 15445:
+		return setClass(setBA(setAB(x.Clone()), y), LeftOpen)
+	case 6147:
+		return setClass(setBA(y.Clone(), x), Open)
+	case 12543, 12799, 14028, 6671, 6927, 8204, 25100, 19660:
+		return setClass(setBA(setAB(y.Clone()), x), Open)
+	case 22284, 15615, 16844, 9727, 9983, 11212:
+		return setClass(setBA(setAB(y.Clone()), x), LeftOpen)
+	case 18961, 18517, 9557, 10513, 10069:
+		return setClass(setBA(setAB(x.Clone()), y), LeftClosed)
+	case 18721, 10273, 9829, 9317:
+		return setClass(setAB(x.Clone()), Degenerate)
+	case 18705, 10257, 9813, 9301:
+		return setClass(setBA(setAB(x.Clone()), y), Closed)
+	case 13055, 13772, 7183, 6415, 7948, 24844, 18687, 19404:
+		return setClass(setBA(setAB(y.Clone()), x), LeftClosed)
+	case 22028, 15871, 16588, 10239, 9471, 10956:
+		return setClass(setBA(setAB(y.Clone()), x), Closed)
+	case 22024, 15867, 16584, 10235, 9467, 10952:
+		return setClass(setAB(y.Clone()), Degenerate)
+	}
+	return setClass(x.Clone(), Empty)
+}
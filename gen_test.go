@@ -157,6 +157,8 @@ package interval
 	w(prolog)
 	genIntersection(w)
 	genUnion(w)
+	genHull(w)
+	genGap(w)
 	src, err := format.Source(buf.Bytes())
 	if err != nil {
 		t.Logf("%s", buf.Bytes())
@@ -246,6 +248,149 @@ func Union(x, y Interface) Interface {
 `)
 }
 
+func genHull(w func(string, ...interface{})) {
+	w(`// Hull returns the convex hull of xs, that is the smallest interval containing
+// all of xs. Unlike Union, the hull of disjoint intervals is not nil. Empty
+// intervals do not contribute to the hull. The hull of no intervals is nil.
+func Hull(xs ...Interface) Interface {
+	if len(xs) == 0 {
+		return nil
+	}
+
+	r := xs[0].Clone()
+	for _, x := range xs[1:] {
+		r = hull(r, x)
+	}
+	return r
+}
+
+func hull(x, y Interface) Interface {
+`)
+	w("x, y, h := hash(x, y)\n")
+	w("switch h {\n")
+	m := deriveRules(analyzeHull)
+	var a []string
+	for k := range m {
+		a = append(a, k)
+	}
+	sort.Strings(a)
+	for _, s := range a {
+		if s == "{}" {
+			continue
+		}
+
+		w("case")
+		for i, k := range m[s] {
+			switch i {
+			case 0:
+				w(" ")
+			default:
+				w(", ")
+			}
+			w("%d", k.hash())
+		}
+		w(":\n")
+		genResult(s, w)
+	}
+	w("}\n")
+	w(`return setClass(x.Clone(), Empty)
+}
+`)
+}
+
+func genGap(w func(string, ...interface{})) {
+	w(`// Gap returns the interval lying strictly between x and y if their union is
+// not an interval. Otherwise, that is when x and y overlap or touch or any of
+// them is empty, the result is Empty. Closedness of the edges of the gap is the
+// opposite of that of the respective edges of x and y, for example the gap
+// between [1, 3) and (5, 9] is [3, 5].
+func Gap(x, y Interface) Interface {
+`)
+	w("x, y, h := hash(x, y)\n")
+	w("switch h {\n")
+	m := deriveRules(analyzeGap)
+	var a []string
+	for k := range m {
+		a = append(a, k)
+	}
+	sort.Strings(a)
+	for _, s := range a {
+		if s == "{}" {
+			continue
+		}
+
+		w("case")
+		for i, k := range m[s] {
+			switch i {
+			case 0:
+				w(" ")
+			default:
+				w(", ")
+			}
+			w("%d", k.hash())
+		}
+		w(":\n")
+		genGapResult(s, w)
+	}
+	w("}\n")
+	w(`return setClass(x.Clone(), Empty)
+}
+`)
+}
+
+// genGapResult is like genResult but the lower edge of the gap is the upper
+// edge of one of the intervals and vice versa.
+func genGapResult(s string, w func(string, ...interface{})) {
+	a := strings.Split(s, ", ")
+	if a[0] == a[1] {
+		switch a[0] {
+		case "[XB":
+			w("return setClass(setAB(x.Clone()), Degenerate)\n")
+		case "[YB":
+			w("return setClass(setAB(y.Clone()), Degenerate)\n")
+		default:
+			panic("internal error")
+		}
+		return
+	}
+
+	var r string
+	switch a[0][1:] {
+	case "XA":
+		r = "x.Clone()"
+	case "XB":
+		r = "setAB(x.Clone())"
+	case "YA":
+		r = "y.Clone()"
+	case "YB":
+		r = "setAB(y.Clone())"
+	default:
+		panic("internal error")
+	}
+	switch a[1][1:] {
+	case "XA":
+		r = fmt.Sprintf("setBA(%s, x)", r)
+	case "YA":
+		r = fmt.Sprintf("setBA(%s, y)", r)
+	default:
+		panic("internal error")
+	}
+	var c string
+	switch a[0][:1] + a[1][:1] {
+	case "((":
+		c = "Open"
+	case "[(":
+		c = "LeftClosed"
+	case "([":
+		c = "LeftOpen"
+	case "[[":
+		c = "Closed"
+	default:
+		panic("internal error")
+	}
+	w("return setClass(%s, %s)\n", r, c)
+}
+
 func genResult(s string, w func(string, ...interface{})) {
 	a := strings.Split(s, ", ")
 	switch a[0] {
@@ -287,6 +432,8 @@ func genResult(s string, w func(string, ...interface{})) {
 			w("return setClass(x.Clone(), LeftBoundedClosed)\n")
 		case "[XA":
 			w("return setClass(x.Clone(), Degenerate)\n")
+		case "[YA":
+			w("return setClass(setBA(x.Clone(), y), Closed)\n")
 		case "(XB":
 			w("return setClass(x.Clone(), LeftClosed)\n")
 		case "[XB":
@@ -636,3 +783,172 @@ func analyzeUnion(xc, yc Class) map[key]string {
 	}
 	return r
 }
+
+func analyzeHull(xc, yc Class) map[key]string {
+	m := map[key][]string{}
+	for _, x := range samples(xc) {
+		for _, y := range samples(yc) {
+			if x.hasA() && y.hasA() && x.a != y.a && abs(x.a-y.a) <= 10 ||
+				x.hasA() && y.hasB() && x.a != y.b && abs(x.a-y.b) <= 10 ||
+				x.hasB() && y.hasA() && x.b != y.a && abs(x.b-y.a) <= 10 ||
+				x.hasB() && y.hasB() && x.b != y.b && abs(x.b-y.b) <= 10 {
+				continue
+			}
+			var from, to string
+			for n := negInf; n <= posInf; n += 5 {
+				if x.has(n) || y.has(n) {
+					var s string
+					switch {
+					case n == negInf, n == posInf:
+						s = "inf"
+					case x.hasA() && x.includesA() && n == x.a:
+						s = "[XA"
+					case x.hasA() && !x.includesA() && n == x.a+5:
+						s = "(XA"
+					case x.hasB() && !x.includesB() && n == x.b-5:
+						s = "(XB"
+					case x.hasB() && x.includesB() && n == x.b:
+						s = "[XB"
+					case y.hasA() && y.includesA() && n == y.a:
+						s = "[YA"
+					case y.hasA() && !y.includesA() && n == y.a+5:
+						s = "(YA"
+					case y.hasB() && !y.includesB() && n == y.b-5:
+						s = "(YB"
+					case y.hasB() && y.includesB() && n == y.b:
+						s = "[YB"
+					}
+					if from == "" {
+						from = s
+					}
+					to = s
+				}
+			}
+			var k key
+			if x.hasA() && y.hasA() {
+				k.xaya = strconv.Itoa(cmp(x.a, y.a))
+			}
+			if x.hasA() && y.hasB() {
+				k.xayb = strconv.Itoa(cmp(x.a, y.b))
+			}
+			if x.hasB() && y.hasA() {
+				k.xbya = strconv.Itoa(cmp(x.b, y.a))
+			}
+			if x.hasB() && y.hasB() {
+				k.xbyb = strconv.Itoa(cmp(x.b, y.b))
+			}
+			if from != "" {
+				m[k] = append(m[k], from+", "+to)
+				continue
+			}
+
+			m[k] = append(m[k], "{}")
+		}
+	}
+	r := map[key]string{}
+	for k, v := range m {
+		v0 := v[0]
+		for _, v := range v[1:] {
+			if v != v0 {
+				panic("internal error")
+			}
+		}
+		r[k] = v0
+	}
+	return r
+}
+
+func analyzeGap(xc, yc Class) map[key]string {
+	m := map[key][]string{}
+	for _, x := range samples(xc) {
+		for _, y := range samples(yc) {
+			if x.hasA() && y.hasA() && x.a != y.a && abs(x.a-y.a) <= 10 ||
+				x.hasA() && y.hasB() && x.a != y.b && abs(x.a-y.b) <= 10 ||
+				x.hasB() && y.hasA() && x.b != y.a && abs(x.b-y.a) <= 10 ||
+				x.hasB() && y.hasB() && x.b != y.b && abs(x.b-y.b) <= 10 {
+				continue
+			}
+			first, last := posInf+1, negInf-1
+			for n := negInf; n <= posInf; n += 5 {
+				if x.has(n) || y.has(n) {
+					if n < first {
+						first = n
+					}
+					last = n
+				}
+			}
+			var from, to string
+			var fromN, toN int
+			for n := first + 5; n < last; n += 5 {
+				if x.has(n) || y.has(n) {
+					continue
+				}
+
+				// The lower edge of the gap is the upper edge of the
+				// interval on its left and vice versa.
+				var lo, hi string
+				switch {
+				case x.hasB() && x.includesB() && n == x.b+5:
+					lo = "(XB"
+				case x.hasB() && !x.includesB() && n == x.b:
+					lo = "[XB"
+				case x.cls == Degenerate && n == x.a+5:
+					lo = "(XA"
+				case y.hasB() && y.includesB() && n == y.b+5:
+					lo = "(YB"
+				case y.hasB() && !y.includesB() && n == y.b:
+					lo = "[YB"
+				case y.cls == Degenerate && n == y.a+5:
+					lo = "(YA"
+				}
+				switch {
+				case x.hasA() && x.includesA() && n == x.a-5:
+					hi = "(XA"
+				case x.hasA() && !x.includesA() && n == x.a:
+					hi = "[XA"
+				case y.hasA() && y.includesA() && n == y.a-5:
+					hi = "(YA"
+				case y.hasA() && !y.includesA() && n == y.a:
+					hi = "[YA"
+				}
+				if from == "" {
+					from, fromN = lo, n
+				}
+				to, toN = hi, n
+			}
+			if from != "" && fromN == toN {
+				to = from
+			}
+			var k key
+			if x.hasA() && y.hasA() {
+				k.xaya = strconv.Itoa(cmp(x.a, y.a))
+			}
+			if x.hasA() && y.hasB() {
+				k.xayb = strconv.Itoa(cmp(x.a, y.b))
+			}
+			if x.hasB() && y.hasA() {
+				k.xbya = strconv.Itoa(cmp(x.b, y.a))
+			}
+			if x.hasB() && y.hasB() {
+				k.xbyb = strconv.Itoa(cmp(x.b, y.b))
+			}
+			if from != "" {
+				m[k] = append(m[k], from+", "+to)
+				continue
+			}
+
+			m[k] = append(m[k], "{}")
+		}
+	}
+	r := map[key]string{}
+	for k, v := range m {
+		v0 := v[0]
+		for _, v := range v[1:] {
+			if v != v0 {
+				panic("internal error")
+			}
+		}
+		r[k] = v0
+	}
+	return r
+}
@@ -12,6 +12,12 @@
 // may be non empty, like for example the open interval (1, 2), but  no integer
 // value lies between the bounds.
 //
+// Clamp and Distance are provided by all the concrete interval types except
+// BigRat, whose values are dense, and String. The transforms, like Translate
+// or Scale, are limited to the bound types satisfying Number, that is they do
+// not support Int128, BigInt, BigRat and String. Time provides its own
+// transform methods.
+//
 // See also: http://en.wikipedia.org/wiki/Interval_(mathematics)
 package interval
 
@@ -102,12 +108,53 @@ type Interface interface {
 	SetBA(other Interface)
 }
 
+// TypeError reports operands of a binary operation having different concrete
+// types.
+type TypeError struct {
+	X, Y Interface
+}
+
+// Error implements error.
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("interval: mismatched interval types %T and %T", e.X, e.Y)
+}
+
 func compareBA(x, y Interface) int            { return -y.CompareAB(x) }
 func setAB(x Interface) Interface             { x.SetAB(); return x }
 func setB(x, y Interface) Interface           { x.SetB(y); return x }
 func setBA(x, y Interface) Interface          { x.SetBA(y); return x }
 func setClass(x Interface, c Class) Interface { x.SetClass(c); return x }
 
+// as returns other as a T or panics with a *TypeError if other is not a T.
+func as[T Interface](i, other Interface) T {
+	o, ok := other.(T)
+	if !ok {
+		panic(&TypeError{i, other})
+	}
+
+	return o
+}
+
+// hasA reports whether intervals of class c have the bound a.
+func hasA(c Class) bool {
+	switch c {
+	case Degenerate, Open, Closed, LeftOpen, LeftClosed, LeftBoundedOpen, LeftBoundedClosed:
+		return true
+	}
+
+	return false
+}
+
+// hasB reports whether intervals of class c have the bound b.
+func hasB(c Class) bool {
+	switch c {
+	case Open, Closed, LeftOpen, LeftClosed, RightBoundedOpen, RightBoundedClosed:
+		return true
+	}
+
+	return false
+}
+
 func str(c Class, a, b interface{}) string {
 	switch c {
 	case Unbounded:
@@ -187,7 +234,7 @@ func enc(n int) int {
 
 // Float32 is an interval having float32 bounds.
 //
-// Note: Using NaNs as bounds has undefined behavior.
+// Note: Using NaNs as bounds has undefined behavior. Validate reports them.
 type Float32 struct {
 	Cls  Class
 	A, B float32
@@ -207,11 +254,11 @@ func (i *Float32) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Float32) CompareAA(other Interface) int {
-	if i.A < other.(*Float32).A {
+	if i.A < as[*Float32](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Float32).A {
+	if i.A > as[*Float32](i, other).A {
 		return 1
 	}
 
@@ -220,11 +267,11 @@ func (i *Float32) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Float32) CompareAB(other Interface) int {
-	if i.A < other.(*Float32).B {
+	if i.A < as[*Float32](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Float32).B {
+	if i.A > as[*Float32](i, other).B {
 		return 1
 	}
 
@@ -233,11 +280,11 @@ func (i *Float32) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Float32) CompareBB(other Interface) int {
-	if i.B < other.(*Float32).B {
+	if i.B < as[*Float32](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Float32).B {
+	if i.B > as[*Float32](i, other).B {
 		return 1
 	}
 
@@ -248,14 +295,14 @@ func (i *Float32) CompareBB(other Interface) int {
 func (i *Float32) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Float32) SetB(other Interface) { i.B = other.(*Float32).B }
+func (i *Float32) SetB(other Interface) { i.B = as[*Float32](i, other).B }
 
 // SetBA implements Interface.
-func (i *Float32) SetBA(other Interface) { i.B = other.(*Float32).A }
+func (i *Float32) SetBA(other Interface) { i.B = as[*Float32](i, other).A }
 
 // Float64 is an interval having float64 bounds.
 //
-// Note: Using NaNs as bounds has undefined behavior.
+// Note: Using NaNs as bounds has undefined behavior. Validate reports them.
 type Float64 struct {
 	Cls  Class
 	A, B float64
@@ -275,11 +322,11 @@ func (i *Float64) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Float64) CompareAA(other Interface) int {
-	if i.A < other.(*Float64).A {
+	if i.A < as[*Float64](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Float64).A {
+	if i.A > as[*Float64](i, other).A {
 		return 1
 	}
 
@@ -288,11 +335,11 @@ func (i *Float64) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Float64) CompareAB(other Interface) int {
-	if i.A < other.(*Float64).B {
+	if i.A < as[*Float64](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Float64).B {
+	if i.A > as[*Float64](i, other).B {
 		return 1
 	}
 
@@ -301,11 +348,11 @@ func (i *Float64) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Float64) CompareBB(other Interface) int {
-	if i.B < other.(*Float64).B {
+	if i.B < as[*Float64](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Float64).B {
+	if i.B > as[*Float64](i, other).B {
 		return 1
 	}
 
@@ -316,10 +363,10 @@ func (i *Float64) CompareBB(other Interface) int {
 func (i *Float64) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Float64) SetB(other Interface) { i.B = other.(*Float64).B }
+func (i *Float64) SetB(other Interface) { i.B = as[*Float64](i, other).B }
 
 // SetBA implements Interface.
-func (i *Float64) SetBA(other Interface) { i.B = other.(*Float64).A }
+func (i *Float64) SetBA(other Interface) { i.B = as[*Float64](i, other).A }
 
 // Int8 is an interval having int8 bounds.
 type Int8 struct {
@@ -341,11 +388,11 @@ func (i *Int8) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Int8) CompareAA(other Interface) int {
-	if i.A < other.(*Int8).A {
+	if i.A < as[*Int8](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Int8).A {
+	if i.A > as[*Int8](i, other).A {
 		return 1
 	}
 
@@ -354,11 +401,11 @@ func (i *Int8) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Int8) CompareAB(other Interface) int {
-	if i.A < other.(*Int8).B {
+	if i.A < as[*Int8](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Int8).B {
+	if i.A > as[*Int8](i, other).B {
 		return 1
 	}
 
@@ -367,11 +414,11 @@ func (i *Int8) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Int8) CompareBB(other Interface) int {
-	if i.B < other.(*Int8).B {
+	if i.B < as[*Int8](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Int8).B {
+	if i.B > as[*Int8](i, other).B {
 		return 1
 	}
 
@@ -382,10 +429,10 @@ func (i *Int8) CompareBB(other Interface) int {
 func (i *Int8) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Int8) SetB(other Interface) { i.B = other.(*Int8).B }
+func (i *Int8) SetB(other Interface) { i.B = as[*Int8](i, other).B }
 
 // SetBA implements Interface.
-func (i *Int8) SetBA(other Interface) { i.B = other.(*Int8).A }
+func (i *Int8) SetBA(other Interface) { i.B = as[*Int8](i, other).A }
 
 // Int16 is an interval having int16 bounds.
 type Int16 struct {
@@ -407,11 +454,11 @@ func (i *Int16) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Int16) CompareAA(other Interface) int {
-	if i.A < other.(*Int16).A {
+	if i.A < as[*Int16](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Int16).A {
+	if i.A > as[*Int16](i, other).A {
 		return 1
 	}
 
@@ -420,11 +467,11 @@ func (i *Int16) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Int16) CompareAB(other Interface) int {
-	if i.A < other.(*Int16).B {
+	if i.A < as[*Int16](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Int16).B {
+	if i.A > as[*Int16](i, other).B {
 		return 1
 	}
 
@@ -433,11 +480,11 @@ func (i *Int16) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Int16) CompareBB(other Interface) int {
-	if i.B < other.(*Int16).B {
+	if i.B < as[*Int16](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Int16).B {
+	if i.B > as[*Int16](i, other).B {
 		return 1
 	}
 
@@ -448,10 +495,10 @@ func (i *Int16) CompareBB(other Interface) int {
 func (i *Int16) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Int16) SetB(other Interface) { i.B = other.(*Int16).B }
+func (i *Int16) SetB(other Interface) { i.B = as[*Int16](i, other).B }
 
 // SetBA implements Interface.
-func (i *Int16) SetBA(other Interface) { i.B = other.(*Int16).A }
+func (i *Int16) SetBA(other Interface) { i.B = as[*Int16](i, other).A }
 
 // Int32 is an interval having int32 bounds.
 type Int32 struct {
@@ -473,11 +520,11 @@ func (i *Int32) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Int32) CompareAA(other Interface) int {
-	if i.A < other.(*Int32).A {
+	if i.A < as[*Int32](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Int32).A {
+	if i.A > as[*Int32](i, other).A {
 		return 1
 	}
 
@@ -486,11 +533,11 @@ func (i *Int32) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Int32) CompareAB(other Interface) int {
-	if i.A < other.(*Int32).B {
+	if i.A < as[*Int32](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Int32).B {
+	if i.A > as[*Int32](i, other).B {
 		return 1
 	}
 
@@ -499,11 +546,11 @@ func (i *Int32) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Int32) CompareBB(other Interface) int {
-	if i.B < other.(*Int32).B {
+	if i.B < as[*Int32](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Int32).B {
+	if i.B > as[*Int32](i, other).B {
 		return 1
 	}
 
@@ -514,10 +561,10 @@ func (i *Int32) CompareBB(other Interface) int {
 func (i *Int32) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Int32) SetB(other Interface) { i.B = other.(*Int32).B }
+func (i *Int32) SetB(other Interface) { i.B = as[*Int32](i, other).B }
 
 // SetBA implements Interface.
-func (i *Int32) SetBA(other Interface) { i.B = other.(*Int32).A }
+func (i *Int32) SetBA(other Interface) { i.B = as[*Int32](i, other).A }
 
 // Int64 is an interval having int64 bounds.
 type Int64 struct {
@@ -539,11 +586,11 @@ func (i *Int64) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Int64) CompareAA(other Interface) int {
-	if i.A < other.(*Int64).A {
+	if i.A < as[*Int64](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Int64).A {
+	if i.A > as[*Int64](i, other).A {
 		return 1
 	}
 
@@ -552,11 +599,11 @@ func (i *Int64) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Int64) CompareAB(other Interface) int {
-	if i.A < other.(*Int64).B {
+	if i.A < as[*Int64](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Int64).B {
+	if i.A > as[*Int64](i, other).B {
 		return 1
 	}
 
@@ -565,11 +612,11 @@ func (i *Int64) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Int64) CompareBB(other Interface) int {
-	if i.B < other.(*Int64).B {
+	if i.B < as[*Int64](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Int64).B {
+	if i.B > as[*Int64](i, other).B {
 		return 1
 	}
 
@@ -580,10 +627,10 @@ func (i *Int64) CompareBB(other Interface) int {
 func (i *Int64) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Int64) SetB(other Interface) { i.B = other.(*Int64).B }
+func (i *Int64) SetB(other Interface) { i.B = as[*Int64](i, other).B }
 
 // SetBA implements Interface.
-func (i *Int64) SetBA(other Interface) { i.B = other.(*Int64).A }
+func (i *Int64) SetBA(other Interface) { i.B = as[*Int64](i, other).A }
 
 // Int128 is an interval having Int128 bounds.
 type Int128 struct {
@@ -604,22 +651,22 @@ func (i *Int128) SetClass(c Class) { i.Cls = c }
 func (i *Int128) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
-func (i *Int128) CompareAA(other Interface) int { return i.A.Cmp(other.(*Int128).A) }
+func (i *Int128) CompareAA(other Interface) int { return i.A.Cmp(as[*Int128](i, other).A) }
 
 // CompareAB implements Interface.
-func (i *Int128) CompareAB(other Interface) int { return i.A.Cmp(other.(*Int128).B) }
+func (i *Int128) CompareAB(other Interface) int { return i.A.Cmp(as[*Int128](i, other).B) }
 
 // CompareBB implements Interface.
-func (i *Int128) CompareBB(other Interface) int { return i.B.Cmp(other.(*Int128).B) }
+func (i *Int128) CompareBB(other Interface) int { return i.B.Cmp(as[*Int128](i, other).B) }
 
 // SetAB implements Interface.
 func (i *Int128) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Int128) SetB(other Interface) { i.B = other.(*Int128).B }
+func (i *Int128) SetB(other Interface) { i.B = as[*Int128](i, other).B }
 
 // SetBA implements Interface.
-func (i *Int128) SetBA(other Interface) { i.B = other.(*Int128).A }
+func (i *Int128) SetBA(other Interface) { i.B = as[*Int128](i, other).A }
 
 // Int is an interval having int bounds.
 type Int struct {
@@ -641,11 +688,11 @@ func (i *Int) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Int) CompareAA(other Interface) int {
-	if i.A < other.(*Int).A {
+	if i.A < as[*Int](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Int).A {
+	if i.A > as[*Int](i, other).A {
 		return 1
 	}
 
@@ -654,11 +701,11 @@ func (i *Int) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Int) CompareAB(other Interface) int {
-	if i.A < other.(*Int).B {
+	if i.A < as[*Int](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Int).B {
+	if i.A > as[*Int](i, other).B {
 		return 1
 	}
 
@@ -667,11 +714,11 @@ func (i *Int) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Int) CompareBB(other Interface) int {
-	if i.B < other.(*Int).B {
+	if i.B < as[*Int](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Int).B {
+	if i.B > as[*Int](i, other).B {
 		return 1
 	}
 
@@ -682,10 +729,10 @@ func (i *Int) CompareBB(other Interface) int {
 func (i *Int) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Int) SetB(other Interface) { i.B = other.(*Int).B }
+func (i *Int) SetB(other Interface) { i.B = as[*Int](i, other).B }
 
 // SetBA implements Interface.
-func (i *Int) SetBA(other Interface) { i.B = other.(*Int).A }
+func (i *Int) SetBA(other Interface) { i.B = as[*Int](i, other).A }
 
 // Byte is an interval having byte bounds.
 type Byte struct {
@@ -707,11 +754,11 @@ func (i *Byte) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Byte) CompareAA(other Interface) int {
-	if i.A < other.(*Byte).A {
+	if i.A < as[*Byte](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Byte).A {
+	if i.A > as[*Byte](i, other).A {
 		return 1
 	}
 
@@ -720,11 +767,11 @@ func (i *Byte) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Byte) CompareAB(other Interface) int {
-	if i.A < other.(*Byte).B {
+	if i.A < as[*Byte](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Byte).B {
+	if i.A > as[*Byte](i, other).B {
 		return 1
 	}
 
@@ -733,11 +780,11 @@ func (i *Byte) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Byte) CompareBB(other Interface) int {
-	if i.B < other.(*Byte).B {
+	if i.B < as[*Byte](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Byte).B {
+	if i.B > as[*Byte](i, other).B {
 		return 1
 	}
 
@@ -748,10 +795,10 @@ func (i *Byte) CompareBB(other Interface) int {
 func (i *Byte) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Byte) SetB(other Interface) { i.B = other.(*Byte).B }
+func (i *Byte) SetB(other Interface) { i.B = as[*Byte](i, other).B }
 
 // SetBA implements Interface.
-func (i *Byte) SetBA(other Interface) { i.B = other.(*Byte).A }
+func (i *Byte) SetBA(other Interface) { i.B = as[*Byte](i, other).A }
 
 // Uint16 is an interval having uint16 bounds.
 type Uint16 struct {
@@ -773,11 +820,11 @@ func (i *Uint16) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Uint16) CompareAA(other Interface) int {
-	if i.A < other.(*Uint16).A {
+	if i.A < as[*Uint16](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Uint16).A {
+	if i.A > as[*Uint16](i, other).A {
 		return 1
 	}
 
@@ -786,11 +833,11 @@ func (i *Uint16) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Uint16) CompareAB(other Interface) int {
-	if i.A < other.(*Uint16).B {
+	if i.A < as[*Uint16](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Uint16).B {
+	if i.A > as[*Uint16](i, other).B {
 		return 1
 	}
 
@@ -799,11 +846,11 @@ func (i *Uint16) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Uint16) CompareBB(other Interface) int {
-	if i.B < other.(*Uint16).B {
+	if i.B < as[*Uint16](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Uint16).B {
+	if i.B > as[*Uint16](i, other).B {
 		return 1
 	}
 
@@ -814,10 +861,10 @@ func (i *Uint16) CompareBB(other Interface) int {
 func (i *Uint16) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Uint16) SetB(other Interface) { i.B = other.(*Uint16).B }
+func (i *Uint16) SetB(other Interface) { i.B = as[*Uint16](i, other).B }
 
 // SetBA implements Interface.
-func (i *Uint16) SetBA(other Interface) { i.B = other.(*Uint16).A }
+func (i *Uint16) SetBA(other Interface) { i.B = as[*Uint16](i, other).A }
 
 // Uint32 is an interval having uint32 bounds.
 type Uint32 struct {
@@ -839,11 +886,11 @@ func (i *Uint32) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Uint32) CompareAA(other Interface) int {
-	if i.A < other.(*Uint32).A {
+	if i.A < as[*Uint32](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Uint32).A {
+	if i.A > as[*Uint32](i, other).A {
 		return 1
 	}
 
@@ -852,11 +899,11 @@ func (i *Uint32) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Uint32) CompareAB(other Interface) int {
-	if i.A < other.(*Uint32).B {
+	if i.A < as[*Uint32](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Uint32).B {
+	if i.A > as[*Uint32](i, other).B {
 		return 1
 	}
 
@@ -865,11 +912,11 @@ func (i *Uint32) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Uint32) CompareBB(other Interface) int {
-	if i.B < other.(*Uint32).B {
+	if i.B < as[*Uint32](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Uint32).B {
+	if i.B > as[*Uint32](i, other).B {
 		return 1
 	}
 
@@ -880,10 +927,10 @@ func (i *Uint32) CompareBB(other Interface) int {
 func (i *Uint32) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Uint32) SetB(other Interface) { i.B = other.(*Uint32).B }
+func (i *Uint32) SetB(other Interface) { i.B = as[*Uint32](i, other).B }
 
 // SetBA implements Interface.
-func (i *Uint32) SetBA(other Interface) { i.B = other.(*Uint32).A }
+func (i *Uint32) SetBA(other Interface) { i.B = as[*Uint32](i, other).A }
 
 // Uint64 is an interval having uint64 bounds.
 type Uint64 struct {
@@ -905,11 +952,11 @@ func (i *Uint64) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Uint64) CompareAA(other Interface) int {
-	if i.A < other.(*Uint64).A {
+	if i.A < as[*Uint64](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Uint64).A {
+	if i.A > as[*Uint64](i, other).A {
 		return 1
 	}
 
@@ -918,11 +965,11 @@ func (i *Uint64) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Uint64) CompareAB(other Interface) int {
-	if i.A < other.(*Uint64).B {
+	if i.A < as[*Uint64](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Uint64).B {
+	if i.A > as[*Uint64](i, other).B {
 		return 1
 	}
 
@@ -931,11 +978,11 @@ func (i *Uint64) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Uint64) CompareBB(other Interface) int {
-	if i.B < other.(*Uint64).B {
+	if i.B < as[*Uint64](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Uint64).B {
+	if i.B > as[*Uint64](i, other).B {
 		return 1
 	}
 
@@ -946,10 +993,10 @@ func (i *Uint64) CompareBB(other Interface) int {
 func (i *Uint64) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Uint64) SetB(other Interface) { i.B = other.(*Uint64).B }
+func (i *Uint64) SetB(other Interface) { i.B = as[*Uint64](i, other).B }
 
 // SetBA implements Interface.
-func (i *Uint64) SetBA(other Interface) { i.B = other.(*Uint64).A }
+func (i *Uint64) SetBA(other Interface) { i.B = as[*Uint64](i, other).A }
 
 // Uint is an interval having uint bounds.
 type Uint struct {
@@ -971,11 +1018,11 @@ func (i *Uint) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Uint) CompareAA(other Interface) int {
-	if i.A < other.(*Uint).A {
+	if i.A < as[*Uint](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Uint).A {
+	if i.A > as[*Uint](i, other).A {
 		return 1
 	}
 
@@ -984,11 +1031,11 @@ func (i *Uint) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Uint) CompareAB(other Interface) int {
-	if i.A < other.(*Uint).B {
+	if i.A < as[*Uint](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Uint).B {
+	if i.A > as[*Uint](i, other).B {
 		return 1
 	}
 
@@ -997,11 +1044,11 @@ func (i *Uint) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Uint) CompareBB(other Interface) int {
-	if i.B < other.(*Uint).B {
+	if i.B < as[*Uint](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Uint).B {
+	if i.B > as[*Uint](i, other).B {
 		return 1
 	}
 
@@ -1012,10 +1059,10 @@ func (i *Uint) CompareBB(other Interface) int {
 func (i *Uint) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Uint) SetB(other Interface) { i.B = other.(*Uint).B }
+func (i *Uint) SetB(other Interface) { i.B = as[*Uint](i, other).B }
 
 // SetBA implements Interface.
-func (i *Uint) SetBA(other Interface) { i.B = other.(*Uint).A }
+func (i *Uint) SetBA(other Interface) { i.B = as[*Uint](i, other).A }
 
 // String is an interval having string bounds.
 type String struct {
@@ -1037,11 +1084,11 @@ func (i *String) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *String) CompareAA(other Interface) int {
-	if i.A < other.(*String).A {
+	if i.A < as[*String](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*String).A {
+	if i.A > as[*String](i, other).A {
 		return 1
 	}
 
@@ -1050,11 +1097,11 @@ func (i *String) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *String) CompareAB(other Interface) int {
-	if i.A < other.(*String).B {
+	if i.A < as[*String](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*String).B {
+	if i.A > as[*String](i, other).B {
 		return 1
 	}
 
@@ -1063,11 +1110,11 @@ func (i *String) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *String) CompareBB(other Interface) int {
-	if i.B < other.(*String).B {
+	if i.B < as[*String](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*String).B {
+	if i.B > as[*String](i, other).B {
 		return 1
 	}
 
@@ -1078,10 +1125,10 @@ func (i *String) CompareBB(other Interface) int {
 func (i *String) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *String) SetB(other Interface) { i.B = other.(*String).B }
+func (i *String) SetB(other Interface) { i.B = as[*String](i, other).B }
 
 // SetBA implements Interface.
-func (i *String) SetBA(other Interface) { i.B = other.(*String).A }
+func (i *String) SetBA(other Interface) { i.B = as[*String](i, other).A }
 
 // Time is an interval having time.Time bounds.
 type Time struct {
@@ -1103,11 +1150,11 @@ func (i *Time) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Time) CompareAA(other Interface) int {
-	if i.A.Before(other.(*Time).A) {
+	if i.A.Before(as[*Time](i, other).A) {
 		return -1
 	}
 
-	if i.A.After(other.(*Time).A) {
+	if i.A.After(as[*Time](i, other).A) {
 		return 1
 	}
 
@@ -1116,11 +1163,11 @@ func (i *Time) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Time) CompareAB(other Interface) int {
-	if i.A.Before(other.(*Time).B) {
+	if i.A.Before(as[*Time](i, other).B) {
 		return -1
 	}
 
-	if i.A.After(other.(*Time).B) {
+	if i.A.After(as[*Time](i, other).B) {
 		return 1
 	}
 
@@ -1129,11 +1176,11 @@ func (i *Time) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Time) CompareBB(other Interface) int {
-	if i.B.Before(other.(*Time).B) {
+	if i.B.Before(as[*Time](i, other).B) {
 		return -1
 	}
 
-	if i.B.After(other.(*Time).B) {
+	if i.B.After(as[*Time](i, other).B) {
 		return 1
 	}
 
@@ -1144,10 +1191,10 @@ func (i *Time) CompareBB(other Interface) int {
 func (i *Time) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Time) SetB(other Interface) { i.B = other.(*Time).B }
+func (i *Time) SetB(other Interface) { i.B = as[*Time](i, other).B }
 
 // SetBA implements Interface.
-func (i *Time) SetBA(other Interface) { i.B = other.(*Time).A }
+func (i *Time) SetBA(other Interface) { i.B = as[*Time](i, other).A }
 
 // Duration is an interval having time.Duration bounds.
 type Duration struct {
@@ -1169,11 +1216,11 @@ func (i *Duration) Clone() Interface { j := *i; return &j }
 
 // CompareAA implements Interface.
 func (i *Duration) CompareAA(other Interface) int {
-	if i.A < other.(*Duration).A {
+	if i.A < as[*Duration](i, other).A {
 		return -1
 	}
 
-	if i.A > other.(*Duration).A {
+	if i.A > as[*Duration](i, other).A {
 		return 1
 	}
 
@@ -1182,11 +1229,11 @@ func (i *Duration) CompareAA(other Interface) int {
 
 // CompareAB implements Interface.
 func (i *Duration) CompareAB(other Interface) int {
-	if i.A < other.(*Duration).B {
+	if i.A < as[*Duration](i, other).B {
 		return -1
 	}
 
-	if i.A > other.(*Duration).B {
+	if i.A > as[*Duration](i, other).B {
 		return 1
 	}
 
@@ -1195,11 +1242,11 @@ func (i *Duration) CompareAB(other Interface) int {
 
 // CompareBB implements Interface.
 func (i *Duration) CompareBB(other Interface) int {
-	if i.B < other.(*Duration).B {
+	if i.B < as[*Duration](i, other).B {
 		return -1
 	}
 
-	if i.B > other.(*Duration).B {
+	if i.B > as[*Duration](i, other).B {
 		return 1
 	}
 
@@ -1210,10 +1257,10 @@ func (i *Duration) CompareBB(other Interface) int {
 func (i *Duration) SetAB() { i.A = i.B }
 
 // SetB implements Interface.
-func (i *Duration) SetB(other Interface) { i.B = other.(*Duration).B }
+func (i *Duration) SetB(other Interface) { i.B = as[*Duration](i, other).B }
 
 // SetBA implements Interface.
-func (i *Duration) SetBA(other Interface) { i.B = other.(*Duration).A }
+func (i *Duration) SetBA(other Interface) { i.B = as[*Duration](i, other).A }
 
 // BigInt is an interval having math/big.Int bounds.
 type BigInt struct {
@@ -1244,27 +1291,42 @@ func (i *BigInt) Clone() Interface {
 
 // CompareAA implements Interface.
 func (i *BigInt) CompareAA(other Interface) int {
-	return i.A.Cmp(other.(*BigInt).A)
+	return i.A.Cmp(as[*BigInt](i, other).A)
 }
 
 // CompareAB implements Interface.
 func (i *BigInt) CompareAB(other Interface) int {
-	return i.A.Cmp(other.(*BigInt).B)
+	return i.A.Cmp(as[*BigInt](i, other).B)
 }
 
 // CompareBB implements Interface.
 func (i *BigInt) CompareBB(other Interface) int {
-	return i.B.Cmp(other.(*BigInt).B)
+	return i.B.Cmp(as[*BigInt](i, other).B)
 }
 
 // SetAB implements Interface.
-func (i *BigInt) SetAB() { i.A.Set(i.B) }
+func (i *BigInt) SetAB() {
+	if i.A == nil {
+		i.A = big.NewInt(0)
+	}
+	i.A.Set(i.B)
+}
 
 // SetB implements Interface.
-func (i *BigInt) SetB(other Interface) { i.B.Set(other.(*BigInt).B) }
+func (i *BigInt) SetB(other Interface) {
+	if i.B == nil {
+		i.B = big.NewInt(0)
+	}
+	i.B.Set(as[*BigInt](i, other).B)
+}
 
 // SetBA implements Interface.
-func (i *BigInt) SetBA(other Interface) { i.B.Set(other.(*BigInt).A) }
+func (i *BigInt) SetBA(other Interface) {
+	if i.B == nil {
+		i.B = big.NewInt(0)
+	}
+	i.B.Set(as[*BigInt](i, other).A)
+}
 
 // BigRat is an interval having math/big.Rat bounds.
 type BigRat struct {
@@ -1295,24 +1357,39 @@ func (i *BigRat) Clone() Interface {
 
 // CompareAA implements Interface.
 func (i *BigRat) CompareAA(other Interface) int {
-	return i.A.Cmp(other.(*BigRat).A)
+	return i.A.Cmp(as[*BigRat](i, other).A)
 }
 
 // CompareAB implements Interface.
 func (i *BigRat) CompareAB(other Interface) int {
-	return i.A.Cmp(other.(*BigRat).B)
+	return i.A.Cmp(as[*BigRat](i, other).B)
 }
 
 // CompareBB implements Interface.
 func (i *BigRat) CompareBB(other Interface) int {
-	return i.B.Cmp(other.(*BigRat).B)
+	return i.B.Cmp(as[*BigRat](i, other).B)
 }
 
 // SetAB implements Interface.
-func (i *BigRat) SetAB() { i.A.Set(i.B) }
+func (i *BigRat) SetAB() {
+	if i.A == nil {
+		i.A = big.NewRat(1, 1)
+	}
+	i.A.Set(i.B)
+}
 
 // SetB implements Interface.
-func (i *BigRat) SetB(other Interface) { i.B.Set(other.(*BigRat).B) }
+func (i *BigRat) SetB(other Interface) {
+	if i.B == nil {
+		i.B = big.NewRat(1, 1)
+	}
+	i.B.Set(as[*BigRat](i, other).B)
+}
 
 // SetBA implements Interface.
-func (i *BigRat) SetBA(other Interface) { i.B.Set(other.(*BigRat).A) }
+func (i *BigRat) SetBA(other Interface) {
+	if i.B == nil {
+		i.B = big.NewRat(1, 1)
+	}
+	i.B.Set(as[*BigRat](i, other).A)
+}
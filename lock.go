@@ -0,0 +1,224 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrDeadlock is returned by LockManager.Lock when waiting for the lock would
+// never end.
+var ErrDeadlock = errors.New("interval: deadlock")
+
+// LockMode is the mode of a range lock.
+type LockMode int
+
+// Values of LockMode.
+const (
+	Shared    LockMode = iota // Compatible with shared locks of other owners.
+	Exclusive                 // Incompatible with any lock of other owners.
+)
+
+type lockHolding struct {
+	shared, exclusive Set
+}
+
+type lockRequest struct {
+	x    Interface
+	mode LockMode
+}
+
+func newLockRequest(fn string, x *Uint64, mode LockMode) lockRequest {
+	if mode != Shared && mode != Exclusive {
+		panic(fmt.Errorf("interval: %s: invalid lock mode %v", fn, mode))
+	}
+
+	return lockRequest{x.Clone(), mode}
+}
+
+// LockManager manages shared and exclusive locks over Uint64 ranges, for
+// example regions of a file, held by owners of type O, for example
+// transactions. Ranges may be of any class, so half-open ranges like [0, 4096)
+// and open-ended ranges like [4096, ∞) both work.
+//
+// Like POSIX record locks, the locks of an owner never conflict with each
+// other. Locking a range replaces the mode of any locks the owner already
+// holds on it, upgrading or downgrading them, and unlocking a range may
+// release parts of existing locks.
+//
+// An owner is a single thread of control and must not call Lock while another
+// Lock call for the same owner is in progress.
+//
+// The zero value is a LockManager ready to use. A LockManager must not be
+// copied after first use.
+type LockManager[O comparable] struct {
+	mu      sync.Mutex
+	held    map[O]*lockHolding
+	waiting map[O]lockRequest
+	changed chan struct{} // Closed when locks are released.
+}
+
+// blockers returns the owners holding locks conflicting with r.
+func (m *LockManager[O]) blockers(owner O, r lockRequest) (b []O) {
+	for o, h := range m.held {
+		if o != owner && (h.exclusive.Overlaps(r.x) || r.mode == Exclusive && h.shared.Overlaps(r.x)) {
+			b = append(b, o)
+		}
+	}
+	return b
+}
+
+// deadlocked reports whether owner waiting for r would close a cycle in the
+// waits-for graph.
+func (m *LockManager[O]) deadlocked(owner O, r lockRequest) bool {
+	seen := map[O]bool{}
+	var visit func(o O, r lockRequest) bool
+	visit = func(o O, r lockRequest) bool {
+		for _, b := range m.blockers(o, r) {
+			if b == owner {
+				return true
+			}
+
+			if seen[b] {
+				continue
+			}
+
+			seen[b] = true
+			if w, ok := m.waiting[b]; ok && visit(b, w) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(owner, r)
+}
+
+// broadcast wakes up all waiters.
+func (m *LockManager[O]) broadcast() {
+	if m.changed != nil {
+		close(m.changed)
+		m.changed = nil
+	}
+}
+
+func (m *LockManager[O]) grant(owner O, r lockRequest) {
+	if m.held == nil {
+		m.held = map[O]*lockHolding{}
+	}
+	h := m.held[owner]
+	if h == nil {
+		h = &lockHolding{}
+		m.held[owner] = h
+	}
+	switch r.mode {
+	case Shared:
+		if h.exclusive.Overlaps(r.x) {
+			h.exclusive = h.exclusive.Remove(r.x)
+			m.broadcast()
+		}
+		h.shared = h.shared.Add(r.x)
+	case Exclusive:
+		h.shared = h.shared.Remove(r.x)
+		h.exclusive = h.exclusive.Add(r.x)
+	}
+}
+
+// Lock locks x in mode for owner, waiting until no other owner holds a
+// conflicting lock. If ctx is done first, its error is returned. If waiting
+// would deadlock, ErrDeadlock is returned. In both cases the locks of owner
+// are not changed. Lock panics if mode is not Shared or Exclusive.
+func (m *LockManager[O]) Lock(ctx context.Context, owner O, x *Uint64, mode LockMode) error {
+	r := newLockRequest("Lock", x, mode)
+	if x.Class() == Empty {
+		return nil
+	}
+
+	m.mu.Lock()
+	for {
+		if len(m.blockers(owner, r)) == 0 {
+			delete(m.waiting, owner)
+			m.grant(owner, r)
+			m.mu.Unlock()
+			return nil
+		}
+
+		if m.deadlocked(owner, r) {
+			delete(m.waiting, owner)
+			m.mu.Unlock()
+			return ErrDeadlock
+		}
+
+		if m.waiting == nil {
+			m.waiting = map[O]lockRequest{}
+		}
+		m.waiting[owner] = r
+		if m.changed == nil {
+			m.changed = make(chan struct{})
+		}
+		changed := m.changed
+		m.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			m.mu.Lock()
+			delete(m.waiting, owner)
+			m.mu.Unlock()
+			return ctx.Err()
+		case <-changed:
+		}
+		m.mu.Lock()
+	}
+}
+
+// TryLock locks x in mode for owner and returns true if no other owner holds
+// a conflicting lock. Otherwise the result is false. TryLock panics if mode is
+// not Shared or Exclusive.
+func (m *LockManager[O]) TryLock(owner O, x *Uint64, mode LockMode) bool {
+	r := newLockRequest("TryLock", x, mode)
+	if x.Class() == Empty {
+		return true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.blockers(owner, r)) != 0 {
+		return false
+	}
+
+	m.grant(owner, r)
+	return true
+}
+
+// Unlock releases the locks of owner on x.
+func (m *LockManager[O]) Unlock(owner O, x *Uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h := m.held[owner]
+	if h == nil || x.Class() == Empty {
+		return
+	}
+
+	h.shared = h.shared.Remove(x)
+	h.exclusive = h.exclusive.Remove(x)
+	if h.shared.Len() == 0 && h.exclusive.Len() == 0 {
+		delete(m.held, owner)
+	}
+	m.broadcast()
+}
+
+// UnlockAll releases all the locks of owner.
+func (m *LockManager[O]) UnlockAll(owner O) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.held[owner]; ok {
+		delete(m.held, owner)
+		m.broadcast()
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waiting waits until owner waits in m.
+func waiting(t *testing.T, m *LockManager[string], owner string) {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		m.mu.Lock()
+		_, ok := m.waiting[owner]
+		m.mu.Unlock()
+		if ok {
+			return
+		}
+	}
+	t.Fatalf("%s: not waiting", owner)
+}
+
+func lockAsync(m *LockManager[string], owner string, x *Uint64, mode LockMode) chan error {
+	ch := make(chan error, 1)
+	go func() { ch <- m.Lock(context.Background(), owner, x, mode) }()
+	return ch
+}
+
+func TestLockManager(t *testing.T) {
+	var m LockManager[string]
+	page0 := &Uint64{LeftClosed, 0, 4096}
+	page1 := &Uint64{LeftClosed, 4096, 8192}
+	tail := &Uint64{LeftBoundedClosed, 8192, 0}
+	if !m.TryLock("a", page0, Shared) || !m.TryLock("b", page0, Shared) {
+		t.Fatal("shared locks conflict")
+	}
+
+	if m.TryLock("c", &Uint64{Degenerate, 4095, 0}, Exclusive) {
+		t.Fatal("exclusive lock granted over shared locks")
+	}
+
+	if !m.TryLock("c", page1, Exclusive) || !m.TryLock("d", tail, Exclusive) {
+		t.Fatal("adjacent locks conflict")
+	}
+
+	if m.TryLock("a", &Uint64{Closed, 1 << 40, 1 << 41}, Shared) {
+		t.Fatal("shared lock granted over open-ended exclusive lock")
+	}
+
+	// Blocking and releasing.
+	ch := lockAsync(&m, "a", page1, Shared)
+	waiting(t, &m, "a")
+	m.Unlock("c", page1)
+	if err := <-ch; err != nil {
+		t.Fatal(err)
+	}
+
+	// Cancellation.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := m.Lock(ctx, "b", tail, Shared); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	if !m.TryLock("a", &Uint64{Closed, 8190, 8191}, Shared) {
+		t.Fatal("cancelled request changed locks")
+	}
+
+	// Upgrade waits for the other shared lock.
+	ch = lockAsync(&m, "a", page0, Exclusive)
+	waiting(t, &m, "a")
+	m.Unlock("b", page0)
+	if err := <-ch; err != nil {
+		t.Fatal(err)
+	}
+
+	if m.TryLock("b", &Uint64{Degenerate, 0, 0}, Shared) {
+		t.Fatal("shared lock granted over upgraded lock")
+	}
+
+	// Downgrade releases waiting readers.
+	ch = lockAsync(&m, "b", page0, Shared)
+	waiting(t, &m, "b")
+	if err := m.Lock(context.Background(), "a", &Uint64{LeftClosed, 0, 100}, Shared); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.TryLock("b", page0, Shared) {
+		t.Fatal("shared lock granted over partially downgraded lock")
+	}
+
+	m.Lock(context.Background(), "a", page0, Shared)
+	if err := <-ch; err != nil {
+		t.Fatal(err)
+	}
+
+	m.UnlockAll("a")
+	m.UnlockAll("b")
+	m.UnlockAll("d")
+	if len(m.held) != 0 {
+		t.Fatalf("locks left: %v", m.held)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic")
+			}
+		}()
+
+		m.TryLock("a", &Uint64{Cls: Empty}, Exclusive+1)
+	}()
+	if len(m.held) != 0 {
+		t.Fatalf("locks left: %v", m.held)
+	}
+}
+
+func TestLockManagerDeadlock(t *testing.T) {
+	var m LockManager[string]
+	x := &Uint64{LeftClosed, 0, 10}
+	y := &Uint64{LeftClosed, 10, 20}
+	m.TryLock("a", x, Exclusive)
+	m.TryLock("b", y, Exclusive)
+	ch := lockAsync(&m, "a", y, Shared)
+	waiting(t, &m, "a")
+	if err := m.Lock(context.Background(), "b", x, Exclusive); err != ErrDeadlock {
+		t.Fatalf("got %v, expected %v", err, ErrDeadlock)
+	}
+
+	m.UnlockAll("b")
+	if err := <-ch; err != nil {
+		t.Fatal(err)
+	}
+
+	// Concurrent upgrades.
+	m.UnlockAll("a")
+	m.TryLock("a", x, Shared)
+	m.TryLock("b", x, Shared)
+	ch = lockAsync(&m, "a", x, Exclusive)
+	waiting(t, &m, "a")
+	if err := m.Lock(context.Background(), "b", x, Exclusive); err != ErrDeadlock {
+		t.Fatalf("got %v, expected %v", err, ErrDeadlock)
+	}
+
+	m.Unlock("b", x)
+	if err := <-ch; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"iter"
+	"sort"
+)
+
+type mapEntry[V any] struct {
+	x Interface
+	v V
+}
+
+// Map associates values with disjoint intervals. Assigning a value to an
+// interval overwrites the values of the overlapped parts of existing entries,
+// splitting them at the edges of the interval as needed. For example, setting
+// [3, 5) to 'b' in a map having [1, 9] set to 'a' produces
+//
+//	[1, 3): a, [3, 5): b, [5, 9]: a
+//
+// All intervals used with a Map must have the same concrete type.
+//
+// The zero value is an empty Map ready to use.
+type Map[V any] struct {
+	entries []mapEntry[V]
+	eq      func(a, b V) bool
+}
+
+// NewMap returns a new, empty Map. If eq is not nil, adjacent entries having
+// values equal according to eq are coalesced into a single entry.
+func NewMap[V any](eq func(a, b V) bool) *Map[V] { return &Map[V]{eq: eq} }
+
+// Len returns the number of entries in m.
+func (m *Map[V]) Len() int { return len(m.entries) }
+
+// search returns the index of the first entry not lying completely before e.
+func (m *Map[V]) search(e edge) int {
+	return sort.Search(len(m.entries), func(i int) bool {
+		return compareEdges(upper(m.entries[i].x), e) > 0
+	})
+}
+
+// Get returns the value associated with the interval containing p, typically
+// a Degenerate interval. The result is false if no entry contains all of p.
+func (m *Map[V]) Get(p Interface) (v V, ok bool) {
+	if p.Class() == Empty {
+		return v, false
+	}
+
+	i := m.search(lower(p))
+	if i == len(m.entries) {
+		return v, false
+	}
+
+	e := m.entries[i]
+	if compareEdges(lower(e.x), lower(p)) > 0 || compareEdges(upper(e.x), upper(p)) < 0 {
+		return v, false
+	}
+
+	return e.v, true
+}
+
+// Set associates v with x, overwriting the values associated with any part of
+// x before.
+func (m *Map[V]) Set(x Interface, v V) {
+	if x.Class() != Empty {
+		m.splice(x, []mapEntry[V]{{x.Clone(), v}})
+	}
+}
+
+// Delete removes any association of the values of x.
+func (m *Map[V]) Delete(x Interface) {
+	if x.Class() != Empty {
+		m.splice(x, nil)
+	}
+}
+
+// splice replaces the parts of the entries overlapping the non empty x by
+// the entries in a, which must lie inside x.
+func (m *Map[V]) splice(x Interface, a []mapEntry[V]) {
+	lo, hi := lower(x), upper(x)
+	i := m.search(lo)
+	j := i + sort.Search(len(m.entries)-i, func(k int) bool {
+		return compareEdges(lower(m.entries[i+k].x), hi) >= 0
+	})
+	var b []mapEntry[V]
+	if i < j {
+		if e := m.entries[i]; compareEdges(lower(e.x), lo) < 0 {
+			b = append(b, mapEntry[V]{span(e.x, lower(e.x), lo), e.v})
+		}
+	}
+	b = append(b, a...)
+	if i < j {
+		if e := m.entries[j-1]; compareEdges(upper(e.x), hi) > 0 {
+			b = append(b, mapEntry[V]{span(e.x, hi, upper(e.x)), e.v})
+		}
+	}
+	m.entries = append(m.entries[:i], append(b, m.entries[j:]...)...)
+	if m.eq == nil {
+		return
+	}
+
+	// Coalesce the new entries with each other and with their neighbours.
+	from, to := max(i-1, 0), min(i+len(b)+1, len(m.entries))
+	r := m.entries[:from]
+	for _, e := range m.entries[from:to] {
+		if n := len(r); n > from {
+			if p := r[n-1]; compareEdges(upper(p.x), lower(e.x)) == 0 && m.eq(p.v, e.v) {
+				r[n-1].x = span(p.x, lower(p.x), upper(e.x))
+				continue
+			}
+		}
+
+		r = append(r, e)
+	}
+	m.entries = append(r, m.entries[to:]...)
+}
+
+// All returns an iterator over all the entries of m in ascending order.
+func (m *Map[V]) All() iter.Seq2[Interface, V] {
+	return func(yield func(Interface, V) bool) {
+		for _, e := range m.entries {
+			if !yield(e.x.Clone(), e.v) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the entries of m overlapping q in ascending
+// order. The intervals yielded are the intersections of the entries with q.
+func (m *Map[V]) Range(q Interface) iter.Seq2[Interface, V] {
+	return func(yield func(Interface, V) bool) {
+		if q.Class() == Empty {
+			return
+		}
+
+		hi := upper(q)
+		for _, e := range m.entries[m.search(lower(q)):] {
+			if compareEdges(lower(e.x), hi) >= 0 || !yield(Intersection(e.x, q), e.v) {
+				return
+			}
+		}
+	}
+}
+
+// AggregateMap associates values with disjoint intervals like Map, but adding
+// a value to an interval combines it with the values already associated with
+// the overlapped parts of existing entries, splitting them as needed. For
+// example, adding 2 to [3, 5) in a map summing its values and having 1 added
+// to [1, 9] produces
+//
+//	[1, 3): 1, [3, 5): 3, [5, 9]: 1
+//
+// All intervals used with an AggregateMap must have the same concrete type.
+type AggregateMap[V any] struct {
+	m       Map[V]
+	combine func(old, v V) V
+}
+
+// NewAggregateMap returns a new, empty AggregateMap using combine to compute
+// the new value of the parts of entries overlapped by an added interval. If eq
+// is not nil, adjacent entries having values equal according to eq are
+// coalesced into a single entry.
+func NewAggregateMap[V any](combine func(old, v V) V, eq func(a, b V) bool) *AggregateMap[V] {
+	return &AggregateMap[V]{Map[V]{eq: eq}, combine}
+}
+
+// Len returns the number of entries in m.
+func (m *AggregateMap[V]) Len() int { return m.m.Len() }
+
+// Get returns the value associated with the interval containing p, typically
+// a Degenerate interval. The result is false if no entry contains all of p.
+func (m *AggregateMap[V]) Get(p Interface) (v V, ok bool) { return m.m.Get(p) }
+
+// Add combines v with the values associated with the parts of x having one
+// and associates v with the other parts of x.
+func (m *AggregateMap[V]) Add(x Interface, v V) {
+	if x.Class() == Empty {
+		return
+	}
+
+	var a []mapEntry[V]
+	gap := func(lo, hi edge) {
+		if g := span(x, lo, hi); g.Class() != Empty {
+			a = append(a, mapEntry[V]{g, v})
+		}
+	}
+	from := lower(x)
+	for y, w := range m.m.Range(x) {
+		gap(from, lower(y))
+		a = append(a, mapEntry[V]{y, m.combine(w, v)})
+		from = upper(y)
+	}
+	gap(from, upper(x))
+	m.m.splice(x, a)
+}
+
+// Delete removes any association of the values of x.
+func (m *AggregateMap[V]) Delete(x Interface) { m.m.Delete(x) }
+
+// All returns an iterator over all the entries of m in ascending order.
+func (m *AggregateMap[V]) All() iter.Seq2[Interface, V] { return m.m.All() }
+
+// Range returns an iterator over the entries of m overlapping q in ascending
+// order. The intervals yielded are the intersections of the entries with q.
+func (m *AggregateMap[V]) Range(q Interface) iter.Seq2[Interface, V] { return m.m.Range(q) }
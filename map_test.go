@@ -0,0 +1,181 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func testMap(t *testing.T, eq func(a, b int) bool) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 1000; i++ {
+		m := NewMap(eq)
+		model := map[int]int{}
+		for j := 0; j < 20; j++ {
+			x := randInterval(rng)
+			v := rng.Intn(3)
+			switch rng.Intn(4) {
+			case 0:
+				m.Delete(x)
+				for n := negInf; n <= posInf; n += 5 {
+					if x.has(n) {
+						delete(model, n)
+					}
+				}
+			default:
+				m.Set(x, v)
+				for n := negInf; n <= posInf; n += 5 {
+					if x.has(n) {
+						model[n] = v
+					}
+				}
+			}
+			for n := negInf; n <= posInf; n += 5 {
+				e, eok := model[n]
+				g, gok := m.Get(&interval{Degenerate, n, 0})
+				if g != e || gok != eok {
+					t.Fatalf("%v: got %v %v, expected %v %v", n, g, gok, e, eok)
+				}
+			}
+			var prev Interface
+			var prevV int
+			for x, v := range m.All() {
+				if x.Class() == Empty {
+					t.Fatal("empty entry")
+				}
+
+				if prev != nil {
+					if compareEdges(upper(prev), lower(x)) > 0 {
+						t.Fatalf("%v %v: not sorted or overlapping", prev, x)
+					}
+
+					if eq != nil && eq(prevV, v) && compareEdges(upper(prev), lower(x)) == 0 {
+						t.Fatalf("%v %v: not coalesced", prev, x)
+					}
+				}
+				prev, prevV = x, v
+			}
+			q := randInterval(rng)
+			seen := map[int]int{}
+			for x, v := range m.Range(q) {
+				for n := negInf; n <= posInf; n += 5 {
+					if x.(*interval).has(n) {
+						if _, ok := seen[n]; ok {
+							t.Fatalf("%v: %v: yielded twice", q, n)
+						}
+
+						seen[n] = v
+					}
+				}
+			}
+			for n := negInf; n <= posInf; n += 5 {
+				e, eok := model[n]
+				eok = eok && q.has(n)
+				g, gok := seen[n]
+				if g != e && eok || gok != eok {
+					t.Fatalf("%v: %v: got %v %v, expected %v %v", q, n, g, gok, e, eok)
+				}
+			}
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	testMap(t, nil)
+	testMap(t, func(a, b int) bool { return a == b })
+}
+
+func ExampleMap() {
+	m := NewMap(func(a, b string) bool { return a == b })
+	m.Set(&Int{Closed, 1, 9}, "a")
+	m.Set(&Int{LeftClosed, 3, 5}, "b")
+	m.Set(&Int{Degenerate, 7, 0}, "c")
+	for x, v := range m.All() {
+		fmt.Print(x, ": ", v, "; ")
+	}
+	fmt.Println(m.Len())
+	m.Set(&Int{Closed, 2, 7}, "a")
+	for x, v := range m.Range(&Int{LeftBoundedOpen, 8, 0}) {
+		fmt.Print(x, ": ", v, "; ")
+	}
+	fmt.Println(m.Len())
+	fmt.Println(m.Get(&Int{Degenerate, 7, 0}))
+	// Output:
+	// [1, 3): a; [3, 5): b; [5, 7): a; {7}: c; (7, 9]: a; 5
+	// (8, 9]: a; 1
+	// a true
+}
+
+func testAggregateMap(t *testing.T, eq func(a, b int) bool) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 1000; i++ {
+		m := NewAggregateMap(func(a, b int) int { return a + b }, eq)
+		model := map[int]int{}
+		for j := 0; j < 20; j++ {
+			x := randInterval(rng)
+			v := rng.Intn(5) - 2
+			switch rng.Intn(5) {
+			case 0:
+				m.Delete(x)
+				for n := negInf; n <= posInf; n += 5 {
+					if x.has(n) {
+						delete(model, n)
+					}
+				}
+			default:
+				m.Add(x, v)
+				for n := negInf; n <= posInf; n += 5 {
+					if x.has(n) {
+						model[n] += v
+					}
+				}
+			}
+			for n := negInf; n <= posInf; n += 5 {
+				e, eok := model[n]
+				g, gok := m.Get(&interval{Degenerate, n, 0})
+				if g != e || gok != eok {
+					t.Fatalf("%v: got %v %v, expected %v %v", n, g, gok, e, eok)
+				}
+			}
+			var prev Interface
+			var prevV int
+			for x, v := range m.All() {
+				if prev != nil {
+					if compareEdges(upper(prev), lower(x)) > 0 {
+						t.Fatalf("%v %v: not sorted or overlapping", prev, x)
+					}
+
+					if eq != nil && eq(prevV, v) && compareEdges(upper(prev), lower(x)) == 0 {
+						t.Fatalf("%v %v: not coalesced", prev, x)
+					}
+				}
+				prev, prevV = x, v
+			}
+		}
+	}
+}
+
+func TestAggregateMap(t *testing.T) {
+	testAggregateMap(t, nil)
+	testAggregateMap(t, func(a, b int) bool { return a == b })
+}
+
+func ExampleAggregateMap() {
+	t0 := time.Date(2015, 1, 1, 10, 0, 0, 0, time.UTC)
+	at := func(min int) time.Time { return t0.Add(time.Duration(min) * time.Minute) }
+	bandwidth := NewAggregateMap(func(a, b int) int { return a + b }, func(a, b int) bool { return a == b })
+	bandwidth.Add(&Time{LeftClosed, at(0), at(60)}, 10)
+	bandwidth.Add(&Time{LeftClosed, at(30), at(90)}, 5)
+	bandwidth.Add(&Time{LeftClosed, at(60), at(90)}, 10)
+	for x, v := range bandwidth.All() {
+		fmt.Printf("[%s, %s): %v\n", x.(*Time).A.Format("15:04"), x.(*Time).B.Format("15:04"), v)
+	}
+	// Output:
+	// [10:00, 10:30): 10
+	// [10:30, 11:30): 15
+}
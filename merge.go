@@ -0,0 +1,56 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import "slices"
+
+// Merge returns the minimal list of disjoint intervals having the same union
+// as xs, in the order defined by Compare. Overlapping and adjacent intervals,
+// like [1, 2) and [2, 3), are merged as by Union. Empty intervals are dropped.
+// All of xs must have the same concrete type.
+//
+// Merge runs in O(n log n). It does not modify xs and the result does not
+// share intervals with xs, but only the intervals that are returned or
+// extended are cloned.
+func Merge(xs []Interface) []Interface {
+	a := make([]Interface, 0, len(xs))
+	for _, x := range xs {
+		if x.Class() != Empty {
+			a = append(a, x)
+		}
+	}
+	slices.SortFunc(a, Compare)
+	r := a[:0]
+	var cur Interface
+	owned := false
+	for _, x := range a {
+		switch {
+		case cur == nil:
+			cur, owned = x, false
+		case compareEdges(upper(x), upper(cur)) <= 0:
+			// x is a subset of cur.
+		case compareEdges(lower(x), upper(cur)) <= 0:
+			// x overlaps or touches cur.
+			if !owned {
+				cur, owned = cur.Clone(), true
+			}
+			setUpper(cur, lower(cur), upper(x))
+		default:
+			if !owned {
+				cur = cur.Clone()
+			}
+			r = append(r, cur)
+			cur, owned = x, false
+		}
+	}
+	if cur == nil {
+		return nil
+	}
+
+	if !owned {
+		cur = cur.Clone()
+	}
+	return append(r, cur)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import "errors"
+
+// Concrete is satisfied by the interval types of this package having bounds of
+// type B, for example Int64 for B being int64.
+type Concrete[B any] interface {
+	~struct {
+		Cls  Class
+		A, B B
+	}
+}
+
+// Pointer is satisfied by *T when *T implements Interface.
+type Pointer[T any] interface {
+	*T
+	Interface
+}
+
+// bounds has the same underlying type as all the types satisfying Concrete[B].
+type bounds[B any] struct {
+	Cls  Class
+	A, B B
+}
+
+// New returns a new interval of class c having bounds a and b, where the class
+// has them. Bounds not used by c are ignored. For example
+//
+//	x, err := interval.New[interval.Int64](interval.LeftClosed, 1, 5)
+//
+// Proper intervals having a == b are normalized: [a, a] becomes Degenerate,
+// (a, a), (a, a] and [a, a) become Empty. Other violations of the invariants
+// checked by Validate, like a > b, are returned as an *InvalidError.
+//
+// Unlike a struct literal, the returned interval does not share any bound
+// values with the arguments. Unless c is Empty or Unbounded, all its bounds
+// are set, even those not used by c. That makes intervals of pointer bound
+// types, like BigInt, safe to pass to Intersection and Union.
+func New[T Concrete[B], B any, P Pointer[T]](c Class, a, b B) (*T, error) {
+	s := bounds[B]{Cls: c, A: a, B: b}
+	switch c {
+	case Degenerate, LeftBoundedOpen, LeftBoundedClosed:
+		s.B = a
+	case RightBoundedOpen, RightBoundedClosed:
+		s.A = b
+	}
+	t := T(s)
+	p := P(&t)
+	if err := Validate(p); err != nil {
+		if !errors.Is(err, ErrEqual) {
+			return nil, err
+		}
+
+		switch c {
+		case Closed:
+			p.SetClass(Degenerate)
+		default:
+			p.SetClass(Empty)
+		}
+	}
+	return (*T)(p.Clone().(P)), nil
+}
+
+// Must returns x if err is nil and panics otherwise. It is intended for use in
+// variable initializations such as
+//
+//	var r = interval.Must(interval.ClosedOf[interval.Int64](1, 5))
+func Must[T any](x *T, err error) *T {
+	if err != nil {
+		panic(err)
+	}
+
+	return x
+}
+
+// OpenOf returns (a, b). See New for details.
+func OpenOf[T Concrete[B], B any, P Pointer[T]](a, b B) (*T, error) {
+	return New[T, B, P](Open, a, b)
+}
+
+// ClosedOf returns [a, b]. See New for details.
+func ClosedOf[T Concrete[B], B any, P Pointer[T]](a, b B) (*T, error) {
+	return New[T, B, P](Closed, a, b)
+}
+
+// LeftOpenOf returns (a, b]. See New for details.
+func LeftOpenOf[T Concrete[B], B any, P Pointer[T]](a, b B) (*T, error) {
+	return New[T, B, P](LeftOpen, a, b)
+}
+
+// LeftClosedOf returns [a, b). See New for details.
+func LeftClosedOf[T Concrete[B], B any, P Pointer[T]](a, b B) (*T, error) {
+	return New[T, B, P](LeftClosed, a, b)
+}
+
+// AtLeast returns [a, ∞). See New for details.
+func AtLeast[T Concrete[B], B any, P Pointer[T]](a B) (*T, error) {
+	var b B
+	return New[T, B, P](LeftBoundedClosed, a, b)
+}
+
+// GreaterThan returns (a, ∞). See New for details.
+func GreaterThan[T Concrete[B], B any, P Pointer[T]](a B) (*T, error) {
+	var b B
+	return New[T, B, P](LeftBoundedOpen, a, b)
+}
+
+// AtMost returns (-∞, b]. See New for details.
+func AtMost[T Concrete[B], B any, P Pointer[T]](b B) (*T, error) {
+	var a B
+	return New[T, B, P](RightBoundedClosed, a, b)
+}
+
+// LessThan returns (-∞, b). See New for details.
+func LessThan[T Concrete[B], B any, P Pointer[T]](b B) (*T, error) {
+	var a B
+	return New[T, B, P](RightBoundedOpen, a, b)
+}
+
+// Point returns {a}. See New for details.
+func Point[T Concrete[B], B any, P Pointer[T]](a B) (*T, error) {
+	var b B
+	return New[T, B, P](Degenerate, a, b)
+}
+
+// EmptyOf returns {}.
+func EmptyOf[T Concrete[B], B any, P Pointer[T]]() *T {
+	var a, b B
+	return Must(New[T, B, P](Empty, a, b))
+}
+
+// All returns (-∞, ∞).
+func All[T Concrete[B], B any, P Pointer[T]]() *T {
+	var a, b B
+	return Must(New[T, B, P](Unbounded, a, b))
+}
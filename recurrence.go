@@ -0,0 +1,94 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"iter"
+	"time"
+)
+
+// Weekly is a recurring interval, like business hours Monday through Friday
+// from 09:00 to 17:00. Every occurrence is a LeftClosed Time interval from
+// Start to End after the midnight of one of Days, as shown by a wall clock in
+// Location. Occurrences thus keep their wall clock times across DST
+// transitions. Wall clock times that do not exist or are ambiguous on a day
+// are resolved as by time.Date.
+type Weekly struct {
+	Days       []time.Weekday
+	Start, End time.Duration  // End may exceed 24 hours for occurrences spanning midnight.
+	Location   *time.Location // Nil means UTC.
+	Except     []*Time        // Removed from the occurrences, for example holidays.
+}
+
+func (w *Weekly) on(d time.Weekday) bool {
+	for _, v := range w.Days {
+		if v == d {
+			return true
+		}
+	}
+	return false
+}
+
+// Occurrences returns an iterator over the intersections of the occurrences of
+// w with window, without the values of Except, in ascending order of their
+// start. Occurrences are not merged, so they may overlap when End-Start
+// exceeds a day. If window has no upper bound, the iteration never ends on
+// its own. Occurrences panics if window is not Empty and has no lower bound.
+func (w *Weekly) Occurrences(window *Time) iter.Seq[*Time] {
+	if window.Cls != Empty && !hasA(window.Cls) {
+		panic(fmt.Errorf("interval: Weekly.Occurrences: window %v has no lower bound", window))
+	}
+
+	return func(yield func(*Time) bool) {
+		if window.Cls == Empty || len(w.Days) == 0 || w.End <= w.Start {
+			return
+		}
+
+		loc := w.Location
+		if loc == nil {
+			loc = time.UTC
+		}
+		var except Set
+		for _, x := range w.Except {
+			except = except.Add(x)
+		}
+		hi := upper(window)
+		y, m, d := window.A.In(loc).Date()
+		for d -= int(w.End/(24*time.Hour)) + 1; ; d++ {
+			occ := &Time{LeftClosed, time.Date(y, m, d, 0, 0, 0, int(w.Start), loc), time.Date(y, m, d, 0, 0, 0, int(w.End), loc)}
+			if compareEdges(lower(occ), hi) >= 0 || except.Contains(&Time{LeftBoundedClosed, occ.A, occ.A}) {
+				return
+			}
+
+			if !w.on(time.Date(y, m, d, 0, 0, 0, 0, loc).Weekday()) || !occ.A.Before(occ.B) {
+				continue
+			}
+
+			x := Intersection(occ, window)
+			if x.Class() == Empty {
+				continue
+			}
+
+			s := Set{}.Add(x)
+			for e := range except.Overlapping(x) {
+				s = s.Remove(e)
+			}
+			for x := range s.All() {
+				if !yield(x.(*Time)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Contains reports whether t is in an occurrence of w.
+func (w *Weekly) Contains(t time.Time) bool {
+	for range w.Occurrences(&Time{Degenerate, t, t}) {
+		return true
+	}
+	return false
+}
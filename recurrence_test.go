@@ -0,0 +1,103 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWeekly(t *testing.T) {
+	prague, err := time.LoadLocation("Europe/Prague")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	date := func(m time.Month, d, h, min int) time.Time { return time.Date(2024, m, d, h, min, 0, 0, prague) }
+	str := func(w *Weekly, window *Time) (s []string) {
+		for x := range w.Occurrences(window) {
+			s = append(s, fmt.Sprintf("%v %s-%s/%v", x.Cls, x.A.In(prague).Format("Mon 02 15:04"), x.B.In(prague).Format("15:04"), x.B.Sub(x.A)))
+		}
+		return s
+	}
+
+	// Night shifts Saturday 22:00 to Sunday 06:00 across the start and the end
+	// of DST.
+	w := &Weekly{Days: []time.Weekday{time.Saturday}, Start: 22 * time.Hour, End: 30 * time.Hour, Location: prague}
+	for _, test := range []struct {
+		window *Time
+		e      string
+	}{
+		{&Time{Closed, date(3, 29, 0, 0), date(4, 1, 0, 0)}, "[LeftClosed Sat 30 22:00-06:00/7h0m0s]"},
+		{&Time{Closed, date(10, 26, 0, 0), date(10, 28, 0, 0)}, "[LeftClosed Sat 26 22:00-06:00/9h0m0s]"},
+		{&Time{Closed, date(3, 31, 1, 0), date(3, 31, 4, 0)}, "[Closed Sun 31 01:00-04:00/2h0m0s]"},
+		{&Time{LeftOpen, date(3, 31, 6, 0), date(4, 6, 22, 0)}, "[Degenerate Sat 06 22:00-22:00/0s]"},
+		{&Time{LeftClosed, date(4, 6, 22, 0), date(4, 13, 23, 0)}, "[LeftClosed Sat 06 22:00-06:00/8h0m0s LeftClosed Sat 13 22:00-23:00/1h0m0s]"},
+	} {
+		if g, e := fmt.Sprint(str(w, test.window)), test.e; g != e {
+			t.Fatalf("%v: got %v, expected %v", test.window, g, e)
+		}
+	}
+
+	// Business hours with a holiday and a shortened day.
+	w = &Weekly{
+		Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start:    9 * time.Hour,
+		End:      17 * time.Hour,
+		Location: prague,
+		Except: []*Time{
+			{LeftClosed, date(4, 1, 0, 0), date(4, 2, 0, 0)},
+			{Closed, date(4, 3, 12, 0), date(4, 3, 13, 0)},
+			{LeftBoundedClosed, date(4, 5, 15, 0), time.Time{}},
+		},
+	}
+	if g, e := fmt.Sprint(str(w, &Time{LeftBoundedClosed, date(3, 29, 12, 0), time.Time{}})),
+		"[LeftClosed Fri 29 12:00-17:00/5h0m0s LeftClosed Tue 02 09:00-17:00/8h0m0s LeftClosed Wed 03 09:00-12:00/3h0m0s Open Wed 03 13:00-17:00/4h0m0s LeftClosed Thu 04 09:00-17:00/8h0m0s LeftClosed Fri 05 09:00-15:00/6h0m0s]"; g != e {
+		t.Fatalf("got %v, expected %v", g, e)
+	}
+
+	for _, test := range []struct {
+		t time.Time
+		e bool
+	}{
+		{date(3, 29, 9, 0), true},
+		{date(3, 29, 17, 0), false},
+		{date(3, 30, 12, 0), false},
+		{date(4, 1, 12, 0), false},
+		{date(4, 3, 12, 30), false},
+		{date(4, 3, 13, 0).Add(time.Nanosecond), true},
+	} {
+		if g, e := w.Contains(test.t), test.e; g != e {
+			t.Fatalf("%v: got %v, expected %v", test.t, g, e)
+		}
+	}
+
+	// The window is checked even if there are no occurrences.
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic")
+			}
+		}()
+
+		(&Weekly{}).Occurrences(&Time{Cls: Unbounded})
+	}()
+}
+
+func ExampleWeekly() {
+	w := &Weekly{
+		Days:  []time.Weekday{time.Monday, time.Wednesday},
+		Start: 9 * time.Hour,
+		End:   17 * time.Hour,
+	}
+	window := &Time{LeftClosed, time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC), time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)}
+	for x := range w.Occurrences(window) {
+		fmt.Println(x.A.Format("Mon 15:04"), x.B.Format("Mon 15:04"))
+	}
+	// Output:
+	// Mon 12:00 Mon 17:00
+	// Wed 09:00 Wed 17:00
+}
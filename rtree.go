@@ -0,0 +1,328 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"container/heap"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+// rnode is a node of an RTree or, if entry is true, an indexed box.
+type rnode[V any] struct {
+	box      Box // Hull of the children.
+	children []*rnode[V]
+	entry    bool
+	v        V
+}
+
+// hullOf returns the hull of the boxes of nodes.
+func hullOf[V any](nodes []*rnode[V]) Box {
+	r := nodes[0].box.Clone()
+	for _, n := range nodes[1:] {
+		r = r.Hull(n.box)
+	}
+	return r
+}
+
+// volume returns the volume of x, +Inf if it cannot be computed.
+func volume(x Box) float64 {
+	if v, ok := x.Volume(); ok && !math.IsNaN(v) {
+		return v
+	}
+
+	return math.Inf(1)
+}
+
+// RTree is a spatial index of boxes associated with values of type V. Overlap
+// and containment queries respect open and closed edges like the Box methods.
+// Choosing where to put new boxes works best with numeric dimensions, like
+// Float64 or Int64. Nearest requires them.
+//
+// All boxes in an RTree must have the same number of dimensions and the same
+// concrete types of dimensions.
+//
+// The zero value is an empty RTree having nodes of at most 16 children ready
+// to use.
+type RTree[V any] struct {
+	root   *rnode[V]
+	height int // Number of levels above the leaves.
+	n      int // Number of boxes.
+	max    int
+}
+
+// NewRTree returns a new, empty RTree having nodes of at most fanout children.
+// If fanout is less than 4, 16 is used.
+func NewRTree[V any](fanout int) *RTree[V] { return &RTree[V]{max: fanout} }
+
+func (t *RTree[V]) init() {
+	if t.max < 4 {
+		t.max = 16
+	}
+}
+
+// Len returns the number of boxes in t.
+func (t *RTree[V]) Len() int { return t.n }
+
+// Insert adds box x associated with v to t.
+func (t *RTree[V]) Insert(x Box, v V) {
+	t.init()
+	e := &rnode[V]{box: x.Clone(), entry: true, v: v}
+	t.n++
+	if t.root == nil {
+		t.root, t.height = &rnode[V]{box: e.box.Clone(), children: []*rnode[V]{e}}, 0
+		return
+	}
+
+	if s := t.insert(t.root, e, t.height); s != nil {
+		r := &rnode[V]{children: []*rnode[V]{t.root, s}}
+		r.box = hullOf(r.children)
+		t.root = r
+		t.height++
+	}
+}
+
+// insert adds e to the subtree n having level levels above the leaves and
+// returns the new sibling of n if n was split.
+func (t *RTree[V]) insert(n, e *rnode[V], level int) *rnode[V] {
+	n.box = n.box.Hull(e.box)
+	switch {
+	case level == 0:
+		n.children = append(n.children, e)
+	default:
+		if s := t.insert(choose(n.children, e.box), e, level-1); s != nil {
+			n.children = append(n.children, s)
+		}
+	}
+	if len(n.children) <= t.max {
+		return nil
+	}
+
+	return splitNode(n)
+}
+
+// choose returns the node of nodes needing the least enlargement to include
+// x, preferring smaller nodes on ties.
+func choose[V any](nodes []*rnode[V], x Box) (r *rnode[V]) {
+	best, bestVol := math.Inf(1), math.Inf(1)
+	for _, n := range nodes {
+		vol := volume(n.box)
+		var d float64
+		if !n.box.Contains(x) {
+			if d = volume(n.box.Hull(x)) - vol; math.IsNaN(d) {
+				d = math.Inf(1)
+			}
+		}
+		if r == nil || d < best || d == best && vol < bestVol {
+			r, best, bestVol = n, d, vol
+		}
+	}
+	return r
+}
+
+// sortDim sorts nodes by their dimension d.
+func sortDim[V any](nodes []*rnode[V], d int) {
+	slices.SortStableFunc(nodes, func(m, n *rnode[V]) int { return Compare(m.box[d], n.box[d]) })
+}
+
+// splitNode moves half of the children of n, sorted along the dimension giving
+// the smallest sum of volumes of the halves, to a new node and returns it.
+func splitNode[V any](n *rnode[V]) *rnode[V] {
+	c := n.children
+	h := len(c) / 2
+	best, bestCost := 0, math.Inf(1)
+	for d := range n.box {
+		sortDim(c, d)
+		if cost := volume(hullOf(c[:h])) + volume(hullOf(c[h:])); d == 0 || cost < bestCost {
+			best, bestCost = d, cost
+		}
+	}
+	sortDim(c, best)
+	s := &rnode[V]{children: slices.Clone(c[h:])}
+	n.children = slices.Clip(c[:h])
+	n.box, s.box = hullOf(n.children), hullOf(s.children)
+	return s
+}
+
+// Load replaces the content of t by boxes, the box boxes[i] associated with
+// values[i], using Sort-Tile-Recursive bulk loading. That is much faster than
+// inserting the boxes one by one and produces a better tree.
+func (t *RTree[V]) Load(boxes []Box, values []V) {
+	if len(boxes) != len(values) {
+		panic(fmt.Errorf("interval: RTree.Load: %v boxes and %v values", len(boxes), len(values)))
+	}
+
+	t.init()
+	t.root, t.height, t.n = nil, 0, len(boxes)
+	if len(boxes) == 0 {
+		return
+	}
+
+	nodes := make([]*rnode[V], len(boxes))
+	for i, x := range boxes {
+		nodes[i] = &rnode[V]{box: x.Clone(), entry: true, v: values[i]}
+	}
+	for level := 0; ; level++ {
+		var parents []*rnode[V]
+		for _, g := range t.tile(nodes, 0) {
+			parents = append(parents, &rnode[V]{box: hullOf(g), children: g})
+		}
+		if len(parents) == 1 {
+			t.root, t.height = parents[0], level
+			return
+		}
+
+		nodes = parents
+	}
+}
+
+// tile partitions nodes to groups of at most t.max nodes by sorting them along
+// the dimension d and cutting them to slabs tiled recursively along the
+// following dimensions.
+func (t *RTree[V]) tile(nodes []*rnode[V], d int) (r [][]*rnode[V]) {
+	sortDim(nodes, d)
+	size := len(nodes)
+	if dims := len(nodes[0].box); d < dims-1 {
+		pages := (len(nodes) + t.max - 1) / t.max
+		slabs := int(math.Ceil(math.Pow(float64(pages), 1/float64(dims-d))))
+		size = t.max * ((pages + slabs - 1) / slabs)
+	}
+	for len(nodes) != 0 {
+		n := min(size, len(nodes))
+		switch {
+		case d < len(nodes[0].box)-1:
+			r = append(r, t.tile(nodes[:n], d+1)...)
+		default:
+			for i := 0; i < n; i += t.max {
+				r = append(r, slices.Clip(nodes[i:min(i+t.max, n)]))
+			}
+		}
+		nodes = nodes[n:]
+	}
+	return r
+}
+
+// search returns an iterator over the entries matched by match in the
+// subtrees not pruned by visit.
+func (t *RTree[V]) search(visit, match func(Box) bool) iter.Seq2[Box, V] {
+	return func(yield func(Box, V) bool) {
+		var walk func(n *rnode[V]) bool
+		walk = func(n *rnode[V]) bool {
+			for _, c := range n.children {
+				switch {
+				case c.entry:
+					if match(c.box) && !yield(c.box.Clone(), c.v) {
+						return false
+					}
+				case visit(c.box):
+					if !walk(c) {
+						return false
+					}
+				}
+			}
+			return true
+		}
+		if t.root != nil && visit(t.root.box) {
+			walk(t.root)
+		}
+	}
+}
+
+// Overlapping returns an iterator over the boxes of t overlapping q and their
+// values, in no particular order.
+func (t *RTree[V]) Overlapping(q Box) iter.Seq2[Box, V] {
+	return t.search(q.Overlaps, q.Overlaps)
+}
+
+// Containing returns an iterator over the boxes of t containing q and their
+// values, in no particular order.
+func (t *RTree[V]) Containing(q Box) iter.Seq2[Box, V] {
+	f := func(x Box) bool { return x.Contains(q) }
+	return t.search(f, f)
+}
+
+// distance returns the square of the Euclidean distance of p from x, +Inf if
+// x is empty. It panics if x has dimensions not of a numeric type.
+func distance(p []float64, x Box) float64 {
+	if len(p) != len(x) {
+		panic(fmt.Errorf("interval: mismatched point and box dimensions %v and %v", len(p), len(x)))
+	}
+
+	if x.IsEmpty() {
+		return math.Inf(1)
+	}
+
+	var r float64
+	for i, v := range x {
+		a, b, ok := floatBounds(v)
+		if !ok {
+			panic(fmt.Errorf("interval: %T is not numeric", v))
+		}
+
+		var d float64
+		switch {
+		case p[i] < a:
+			d = a - p[i]
+		case p[i] > b:
+			d = p[i] - b
+		}
+		r += d * d
+	}
+	return r
+}
+
+type rqueueItem[V any] struct {
+	n *rnode[V]
+	d float64
+}
+
+type rqueue[V any] []rqueueItem[V]
+
+func (q rqueue[V]) Len() int           { return len(q) }
+func (q rqueue[V]) Less(i, j int) bool { return q[i].d < q[j].d }
+func (q rqueue[V]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *rqueue[V]) Push(x any)        { *q = append(*q, x.(rqueueItem[V])) }
+
+func (q *rqueue[V]) Pop() any {
+	n := len(*q) - 1
+	x := (*q)[n]
+	*q = (*q)[:n]
+	return x
+}
+
+// Nearest returns an iterator over the non empty boxes of t and their values
+// in ascending order of their Euclidean distance from the point p, so the
+// first k boxes yielded are the k nearest ones. The distance from a box is the
+// distance from its closure, that is open edges are treated as closed.
+// Nearest panics if the boxes have dimensions not of a numeric type.
+func (t *RTree[V]) Nearest(p []float64) iter.Seq2[Box, V] {
+	return func(yield func(Box, V) bool) {
+		if t.root == nil {
+			return
+		}
+
+		q := &rqueue[V]{{t.root, distance(p, t.root.box)}}
+		for q.Len() != 0 {
+			it := heap.Pop(q).(rqueueItem[V])
+			if math.IsInf(it.d, 1) {
+				return
+			}
+
+			if it.n.entry {
+				if !yield(it.n.box.Clone(), it.n.v) {
+					return
+				}
+
+				continue
+			}
+
+			for _, c := range it.n.children {
+				heap.Push(q, rqueueItem[V]{c, distance(p, c.box)})
+			}
+		}
+	}
+}
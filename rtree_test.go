@@ -0,0 +1,159 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+var rtreeClasses = []Class{Degenerate, Open, Closed, LeftOpen, LeftClosed, Closed, LeftClosed, LeftBoundedOpen, RightBoundedClosed}
+
+func randInt64(rng *rand.Rand, span int) *Int64 {
+	a := int64(rng.Intn(1000))
+	return &Int64{rtreeClasses[rng.Intn(len(rtreeClasses))], a, a + 1 + int64(rng.Intn(span))}
+}
+
+func randInt64Box(rng *rand.Rand, dims, span int) Box {
+	x := make(Box, dims)
+	for i := range x {
+		x[i] = randInt64(rng, span)
+	}
+	return x
+}
+
+// checkRTree verifies the invariants of t.
+func checkRTree[V any](tb testing.TB, t *RTree[V]) {
+	tb.Helper()
+	var n int
+	var walk func(r *rnode[V], level int)
+	walk = func(r *rnode[V], level int) {
+		if len(r.children) == 0 || len(r.children) > t.max {
+			tb.Fatalf("node has %v children", len(r.children))
+		}
+
+		for _, c := range r.children {
+			if !r.box.Contains(c.box) {
+				tb.Fatalf("%v does not contain %v", r.box, c.box)
+			}
+
+			switch {
+			case c.entry:
+				if level != 0 {
+					tb.Fatalf("entry at level %v", level)
+				}
+
+				n++
+			default:
+				walk(c, level-1)
+			}
+		}
+	}
+	if t.root != nil {
+		walk(t.root, t.height)
+	}
+	if g, e := n, t.Len(); g != e {
+		tb.Fatalf("got %v entries, expected %v", g, e)
+	}
+}
+
+func TestRTree(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for _, dims := range []int{1, 2, 3} {
+		for _, n := range []int{0, 1, 10, 100, 2000} {
+			boxes := make([]Box, n)
+			values := make([]int, n)
+			for i := range boxes {
+				boxes[i], values[i] = randInt64Box(rng, dims, 50), i
+			}
+			var inserted RTree[int]
+			for i, x := range boxes {
+				inserted.Insert(x, values[i])
+			}
+			loaded := NewRTree[int](8)
+			loaded.Load(boxes, values)
+			for _, tr := range []*RTree[int]{&inserted, loaded} {
+				checkRTree(t, tr)
+				for i := 0; i < 50; i++ {
+					q := randInt64Box(rng, dims, 200)
+					var g, e []int
+					for x, v := range tr.Overlapping(q) {
+						if !x.Overlaps(q) {
+							t.Fatalf("%v %v: not overlapping", x, q)
+						}
+
+						g = append(g, v)
+					}
+					for i, x := range boxes {
+						if x.Overlaps(q) {
+							e = append(e, i)
+						}
+					}
+					sort.Ints(g)
+					if fmt.Sprint(g) != fmt.Sprint(e) {
+						t.Fatalf("%v: overlapping got %v, expected %v", q, g, e)
+					}
+
+					q = randInt64Box(rng, dims, 5)
+					g, e = nil, nil
+					for _, v := range tr.Containing(q) {
+						g = append(g, v)
+					}
+					for i, x := range boxes {
+						if x.Contains(q) {
+							e = append(e, i)
+						}
+					}
+					sort.Ints(g)
+					if fmt.Sprint(g) != fmt.Sprint(e) {
+						t.Fatalf("%v: containing got %v, expected %v", q, g, e)
+					}
+
+					p := make([]float64, dims)
+					for i := range p {
+						p[i] = float64(rng.Intn(1200) - 100)
+					}
+					var gd, ed []float64
+					for x := range tr.Nearest(p) {
+						if gd = append(gd, distance(p, x)); len(gd) == 10 {
+							break
+						}
+					}
+					for _, x := range boxes {
+						ed = append(ed, distance(p, x))
+					}
+					sort.Float64s(ed)
+					if len(ed) > 10 {
+						ed = ed[:10]
+					}
+					if fmt.Sprint(gd) != fmt.Sprint(ed) {
+						t.Fatalf("%v: nearest got %v, expected %v", p, gd, ed)
+					}
+				}
+			}
+		}
+	}
+}
+
+func ExampleRTree() {
+	var t RTree[string]
+	t.Load([]Box{
+		{&Float64{LeftClosed, 0, 10}, &Float64{LeftClosed, 0, 10}},
+		{&Float64{LeftClosed, 10, 20}, &Float64{LeftClosed, 0, 10}},
+		{&Float64{Closed, 30, 40}, &Float64{Closed, 30, 40}},
+	}, []string{"a", "b", "c"})
+	for _, v := range t.Overlapping(Box{&Float64{Degenerate, 10, 10}, &Float64{Degenerate, 5, 5}}) {
+		fmt.Println(v)
+	}
+	for _, v := range t.Nearest([]float64{25, 25}) {
+		fmt.Println(v)
+		break
+	}
+	// Output:
+	// b
+	// c
+}
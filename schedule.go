@@ -0,0 +1,106 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Free returns the parts of window not covered by any interval in busy, in
+// ascending order. Every argument in busy is typically the busy time of one
+// person, so the result are the slots free for all of them.
+func Free(window *Time, busy ...[]*Time) []*Time {
+	s := Set{}.Add(window)
+	for _, v := range busy {
+		for _, x := range v {
+			s = s.Remove(x)
+		}
+	}
+	r := make([]*Time, 0, s.Len())
+	for x := range s.All() {
+		r = append(r, x.(*Time))
+	}
+	return r
+}
+
+// length returns the length of the non empty x. The result is false if x is
+// unbounded.
+func length(x *Time) (time.Duration, bool) {
+	if !hasA(x.Cls) || !hasB(x.Cls) {
+		return 0, false
+	}
+
+	if x.Cls == Degenerate {
+		return 0, true
+	}
+
+	return x.B.Sub(x.A), true
+}
+
+// FreeSlots returns the first n slots returned by Free having a length of at
+// least d. Unbounded slots are longer than any d. If n is negative, all such
+// slots are returned.
+func FreeSlots(window *Time, d time.Duration, n int, busy ...[]*Time) []*Time {
+	var r []*Time
+	for _, x := range Free(window, busy...) {
+		if n >= 0 && len(r) == n {
+			break
+		}
+
+		if l, ok := length(x); !ok || l >= d {
+			r = append(r, x)
+		}
+	}
+	return r
+}
+
+// ceilGrid returns the earliest origin+k*grid, for any integer k, not before
+// t, in the location of t. The result is false if it is not representable.
+func ceilGrid(t, origin time.Time, grid time.Duration) (time.Time, bool) {
+	n := nanos(t)
+	n.Sub(n, nanos(origin))
+	q, m := n.DivMod(n, big.NewInt(int64(grid)), big.NewInt(0))
+	if m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	q.Mul(q, big.NewInt(int64(grid)))
+	return fromNanos(q.Add(q, nanos(origin)), t.Location())
+}
+
+// Earliest returns the earliest slot [t, t+d) inside window not overlapping
+// any interval in busy, where t is origin plus a whole multiple of grid. A
+// zero grid means any t is acceptable. If there is no such slot, the result
+// is nil. Earliest panics if d or grid are negative, if d is zero or if window
+// has no lower bound.
+func Earliest(window *Time, d, grid time.Duration, origin time.Time, busy ...[]*Time) *Time {
+	if d <= 0 || grid < 0 {
+		panic(fmt.Errorf("interval: Earliest: invalid duration %v or grid %v", d, grid))
+	}
+
+	if c := window.Cls; c != Empty && !hasA(c) {
+		panic(fmt.Errorf("interval: Earliest: window %v has no lower bound", window))
+	}
+
+	for _, x := range Free(window, busy...) {
+		t := x.A
+		if grid != 0 {
+			var ok bool
+			if t, ok = ceilGrid(t, origin, grid); !ok {
+				continue
+			}
+		}
+		if t.Equal(x.A) && lower(x).after {
+			// Time has a resolution of a nanosecond.
+			t = t.Add(max(grid, time.Nanosecond))
+		}
+		slot := &Time{LeftClosed, t, t.Add(d)}
+		if compareEdges(upper(slot), upper(x)) <= 0 {
+			return slot
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// at returns the time h:m on a fixed day in UTC.
+func at(h, m int) time.Time { return time.Date(2024, 3, 4, h, m, 0, 0, time.UTC) }
+
+func TestFree(t *testing.T) {
+	window := &Time{LeftClosed, at(9, 0), at(17, 0)}
+	alice := []*Time{
+		{LeftClosed, at(8, 0), at(9, 30)},
+		{LeftClosed, at(12, 0), at(13, 0)},
+	}
+	bob := []*Time{
+		{Closed, at(10, 0), at(10, 20)},
+		{LeftClosed, at(12, 30), at(14, 0)},
+		{LeftBoundedClosed, at(16, 30), time.Time{}},
+	}
+	hm := func(t time.Time) string { return t.Format("15:04") }
+	str := func(xs []*Time) string {
+		var s string
+		for _, x := range xs {
+			s += fmt.Sprintf("%v%s-%s ", x.Cls, hm(x.A), hm(x.B))
+		}
+		return s
+	}
+	if g, e := str(Free(window, alice, bob)), "LeftClosed09:30-10:00 Open10:20-12:00 LeftClosed14:00-16:30 "; g != e {
+		t.Fatalf("got %q, expected %q", g, e)
+	}
+
+	if g, e := str(FreeSlots(window, 30*time.Minute, -1, alice, bob)), "LeftClosed09:30-10:00 Open10:20-12:00 LeftClosed14:00-16:30 "; g != e {
+		t.Fatalf("got %q, expected %q", g, e)
+	}
+
+	if g, e := str(FreeSlots(window, 2*time.Hour, 2, alice, bob)), "LeftClosed14:00-16:30 "; g != e {
+		t.Fatalf("got %q, expected %q", g, e)
+	}
+
+	if g := FreeSlots(&Time{LeftBoundedClosed, at(9, 0), time.Time{}}, 3*time.Hour, 1, alice); len(g) != 1 || g[0].Cls != LeftBoundedClosed || !g[0].A.Equal(at(13, 0)) {
+		t.Fatalf("got %v", g)
+	}
+
+	for _, test := range []struct {
+		d, grid time.Duration
+		origin  time.Time
+		e       string
+	}{
+		{20 * time.Minute, 0, time.Time{}, "09:30"},
+		{20 * time.Minute, 15 * time.Minute, at(0, 0), "09:30"},
+		{20 * time.Minute, 15 * time.Minute, at(0, 5), "09:35"},
+		{time.Hour, 15 * time.Minute, at(0, 0), "10:30"},
+		{time.Hour, 15 * time.Minute, at(20, 10), "10:25"},
+		{20 * time.Minute, 15 * time.Minute, time.Time{}, "09:30"},
+		{20 * time.Minute, 15 * time.Minute, at(0, 5).AddDate(-1000, 0, 0), "09:35"},
+		{20 * time.Minute, 15 * time.Minute, at(0, 5).AddDate(1000, 0, 0), "09:35"},
+		{2 * time.Hour, 0, time.Time{}, "14:00"},
+		{3 * time.Hour, 0, time.Time{}, ""},
+	} {
+		g := Earliest(window, test.d, test.grid, test.origin, alice, bob)
+		switch {
+		case test.e == "" && g != nil:
+			t.Fatalf("%+v: got %v, expected nil", test, g)
+		case test.e != "" && (g == nil || hm(g.A) != test.e || g.B.Sub(g.A) != test.d || g.Cls != LeftClosed):
+			t.Fatalf("%+v: got %v, expected %v", test, g, test.e)
+		}
+	}
+
+	// The earliest slot cannot start at an open bound.
+	g := Earliest(&Time{Open, at(9, 0), at(10, 0)}, time.Minute, 0, time.Time{})
+	if e := at(9, 0).Add(time.Nanosecond); !g.A.Equal(e) {
+		t.Fatalf("got %v, expected %v", g.A, e)
+	}
+
+	g = Earliest(&Time{Open, at(9, 0), at(10, 0)}, time.Minute, time.Minute, at(0, 0))
+	if e := at(9, 1); !g.A.Equal(e) {
+		t.Fatalf("got %v, expected %v", g.A, e)
+	}
+}
+
+func ExampleFreeSlots() {
+	day := func(h, m int) time.Time { return time.Date(2024, 3, 4, h, m, 0, 0, time.UTC) }
+	window := &Time{LeftClosed, day(9, 0), day(17, 0)}
+	alice := []*Time{{LeftClosed, day(9, 0), day(11, 0)}, {LeftClosed, day(13, 0), day(15, 0)}}
+	bob := []*Time{{LeftClosed, day(11, 15), day(12, 0)}}
+	for _, x := range FreeSlots(window, 30*time.Minute, -1, alice, bob) {
+		fmt.Println(x.A.Format("15:04"), x.B.Format("15:04"))
+	}
+	x := Earliest(window, time.Hour, 15*time.Minute, day(0, 0), alice, bob)
+	fmt.Println(x.A.Format("15:04"), x.B.Format("15:04"))
+	// Output:
+	// 12:00 13:00
+	// 15:00 17:00
+	// 12:00 13:00
+}
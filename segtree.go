@@ -0,0 +1,138 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"sort"
+)
+
+// SegmentTree answers queries about the intervals of a fixed set covering a
+// point. It is built once, in O(n log n), and answers Count and Sum in
+// O(log n) and Covering in O(log n + k), where k is the size of the result.
+type SegmentTree[W Number] struct {
+	cuts []edge      // Sorted, distinct edges of the intervals.
+	cnt  []int       // Number of intervals covering a node.
+	ids  [][]int     // Indices of the intervals covering a node.
+	sum  []W         // Sum of the weights of the intervals covering a node.
+	n    int         // Number of slots, that is len(cuts)+1.
+	xs   []Interface // Clones of the intervals.
+}
+
+// NewSegmentTree returns a SegmentTree of xs, which must all have the same
+// concrete type. The weight of xs[i] is weights[i] or 1 if weights is nil.
+// Empty intervals are never reported as covering a point.
+func NewSegmentTree[W Number](xs []Interface, weights []W) *SegmentTree[W] {
+	if weights != nil && len(weights) != len(xs) {
+		panic("interval: NewSegmentTree: len(weights) != len(xs)")
+	}
+
+	t := &SegmentTree[W]{xs: make([]Interface, len(xs))}
+	for i, x := range xs {
+		x = x.Clone()
+		t.xs[i] = x
+		if x.Class() != Empty {
+			t.cuts = append(t.cuts, lower(x), upper(x))
+		}
+	}
+	sort.Slice(t.cuts, func(i, j int) bool { return compareEdges(t.cuts[i], t.cuts[j]) < 0 })
+	var cuts []edge
+	for i, c := range t.cuts {
+		if i == 0 || compareEdges(cuts[len(cuts)-1], c) != 0 {
+			cuts = append(cuts, c)
+		}
+	}
+	t.cuts = cuts
+	// Slot k lies between cuts[k-1] and cuts[k].
+	t.n = len(cuts) + 1
+	t.cnt = make([]int, 4*t.n)
+	t.ids = make([][]int, 4*t.n)
+	t.sum = make([]W, 4*t.n)
+	for i, x := range t.xs {
+		if x.Class() == Empty {
+			continue
+		}
+
+		w := W(1)
+		if weights != nil {
+			w = weights[i]
+		}
+		t.insert(1, 0, t.n-1, t.cut(lower(x))+1, t.cut(upper(x)), i, w)
+	}
+	return t
+}
+
+// cut returns the index of the cut e.
+func (t *SegmentTree[W]) cut(e edge) int {
+	return sort.Search(len(t.cuts), func(i int) bool { return compareEdges(t.cuts[i], e) >= 0 })
+}
+
+// slot returns the slot containing the non empty p.
+func (t *SegmentTree[W]) slot(p Interface) int {
+	e := lower(p)
+	return sort.Search(len(t.cuts), func(i int) bool { return compareEdges(t.cuts[i], e) > 0 })
+}
+
+// insert adds the interval i having weight w to the nodes covering the slots
+// from through to of the node having the slots lo through hi.
+func (t *SegmentTree[W]) insert(node, lo, hi, from, to, i int, w W) {
+	if from > hi || to < lo {
+		return
+	}
+
+	if from <= lo && hi <= to {
+		t.cnt[node]++
+		t.sum[node] += w
+		t.ids[node] = append(t.ids[node], i)
+		return
+	}
+
+	mid := (lo + hi) / 2
+	t.insert(2*node, lo, mid, from, to, i, w)
+	t.insert(2*node+1, mid+1, hi, from, to, i, w)
+}
+
+// walk calls f for every node on the path from the root to the slot of p.
+func (t *SegmentTree[W]) walk(p Interface, f func(node int)) {
+	if p.Class() == Empty {
+		return
+	}
+
+	s := t.slot(p)
+	node, lo, hi := 1, 0, t.n-1
+	for {
+		f(node)
+		if lo == hi {
+			return
+		}
+
+		switch mid := (lo + hi) / 2; {
+		case s <= mid:
+			node, hi = 2*node, mid
+		default:
+			node, lo = 2*node+1, mid+1
+		}
+	}
+}
+
+// Count returns the number of intervals covering the point p, typically a
+// Degenerate interval.
+func (t *SegmentTree[W]) Count(p Interface) (n int) {
+	t.walk(p, func(node int) { n += t.cnt[node] })
+	return n
+}
+
+// Sum returns the sum of the weights of the intervals covering the point p,
+// typically a Degenerate interval.
+func (t *SegmentTree[W]) Sum(p Interface) (w W) {
+	t.walk(p, func(node int) { w += t.sum[node] })
+	return w
+}
+
+// Covering returns the indices, in unspecified order, of the intervals
+// covering the point p, typically a Degenerate interval.
+func (t *SegmentTree[W]) Covering(p Interface) (r []int) {
+	t.walk(p, func(node int) { r = append(r, t.ids[node]...) })
+	return r
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSegmentTree(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 1000; i++ {
+		var xs []Interface
+		var ws []int
+		for j := rng.Intn(20); j > 0; j-- {
+			xs = append(xs, randInterval(rng))
+			ws = append(ws, rng.Intn(10))
+		}
+		st := NewSegmentTree(xs, ws)
+		for n := negInf; n <= posInf; n += 5 {
+			p := &interval{Degenerate, n, 0}
+			var cnt, sum int
+			var ids []int
+			for j, x := range xs {
+				if x.(*interval).has(n) {
+					cnt++
+					sum += ws[j]
+					ids = append(ids, j)
+				}
+			}
+			if g, e := st.Count(p), cnt; g != e {
+				t.Fatalf("%v %v: count got %v, expected %v", xs, n, g, e)
+			}
+
+			if g, e := st.Sum(p), sum; g != e {
+				t.Fatalf("%v %v: sum got %v, expected %v", xs, n, g, e)
+			}
+
+			g := st.Covering(p)
+			sort.Ints(g)
+			if g, e := fmt.Sprint(g), fmt.Sprint(ids); g != e {
+				t.Fatalf("%v %v: covering got %v, expected %v", xs, n, g, e)
+			}
+		}
+	}
+}
+
+func ExampleSegmentTree() {
+	st := NewSegmentTree([]Interface{
+		&Float64{LeftClosed, 0, 10},
+		&Float64{Closed, 5, 15},
+		&Float64{LeftBoundedOpen, 10, 0},
+	}, []float64{0.5, 1.5, 2})
+	for _, v := range []float64{-1, 5, 10, 12} {
+		p := &Float64{Degenerate, v, 0}
+		fmt.Println(v, st.Count(p), st.Sum(p))
+	}
+	// Output:
+	// -1 0 0
+	// 5 2 2
+	// 10 1 1.5
+	// 12 2 3.5
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"iter"
+)
+
+// Set is a persistent set of values represented as a sorted sequence of
+// disjoint intervals. Adding an interval merges it with the members it
+// overlaps or touches. Removing an interval trims or splits the members it
+// overlaps. A Set is never modified, Add and Remove return a new version
+// sharing most of its structure with the original one. Copying a Set is O(1)
+// and the copy is safe for use by multiple goroutines.
+//
+// All intervals used with a Set must have the same concrete type.
+//
+// The zero value is an empty Set.
+type Set struct {
+	root *setNode
+}
+
+// setNode is a node of an AVL tree, ordered by the lower edges of the
+// intervals. Nodes are never modified once created.
+type setNode struct {
+	x    Interface
+	l, r *setNode
+	h    int // Height.
+	n    int // Number of nodes in the subtree.
+}
+
+func height(t *setNode) int {
+	if t == nil {
+		return 0
+	}
+
+	return t.h
+}
+
+func size(t *setNode) int {
+	if t == nil {
+		return 0
+	}
+
+	return t.n
+}
+
+func newNode(l *setNode, x Interface, r *setNode) *setNode {
+	return &setNode{x, l, r, max(height(l), height(r)) + 1, size(l) + size(r) + 1}
+}
+
+func rotateLeft(t *setNode) *setNode { return newNode(newNode(t.l, t.x, t.r.l), t.r.x, t.r.r) }
+
+func rotateRight(t *setNode) *setNode { return newNode(t.l.l, t.l.x, newNode(t.l.r, t.x, t.r)) }
+
+// join returns the tree having the nodes of l, x and the nodes of r, in this
+// order.
+func join(l *setNode, x Interface, r *setNode) *setNode {
+	switch {
+	case height(l) > height(r)+1:
+		return joinRight(l, x, r)
+	case height(r) > height(l)+1:
+		return joinLeft(l, x, r)
+	default:
+		return newNode(l, x, r)
+	}
+}
+
+// joinRight is join for l taller than r.
+func joinRight(l *setNode, x Interface, r *setNode) *setNode {
+	if height(l.r) <= height(r)+1 {
+		t := newNode(l.r, x, r)
+		if t.h <= height(l.l)+1 {
+			return newNode(l.l, l.x, t)
+		}
+
+		return rotateLeft(newNode(l.l, l.x, rotateRight(t)))
+	}
+
+	t := joinRight(l.r, x, r)
+	u := newNode(l.l, l.x, t)
+	if t.h <= height(l.l)+1 {
+		return u
+	}
+
+	return rotateLeft(u)
+}
+
+// joinLeft is join for r taller than l.
+func joinLeft(l *setNode, x Interface, r *setNode) *setNode {
+	if height(r.l) <= height(l)+1 {
+		t := newNode(l, x, r.l)
+		if t.h <= height(r.r)+1 {
+			return newNode(t, r.x, r.r)
+		}
+
+		return rotateRight(newNode(rotateLeft(t), r.x, r.r))
+	}
+
+	t := joinLeft(l, x, r.l)
+	u := newNode(t, r.x, r.r)
+	if t.h <= height(r.r)+1 {
+		return u
+	}
+
+	return rotateRight(u)
+}
+
+// join2 returns the tree having the nodes of l followed by the nodes of r.
+func join2(l, r *setNode) *setNode {
+	if l == nil {
+		return r
+	}
+
+	l, x := splitLast(l)
+	return join(l, x, r)
+}
+
+// splitLast returns t without its last node and the interval of that node.
+func splitLast(t *setNode) (*setNode, Interface) {
+	if t.r == nil {
+		return t.l, t.x
+	}
+
+	r, x := splitLast(t.r)
+	return join(t.l, t.x, r), x
+}
+
+// split partitions t to the nodes for which left returns true and the rest.
+// left must be true for a prefix of the nodes of t.
+func split(t *setNode, left func(x Interface) bool) (l, r *setNode) {
+	if t == nil {
+		return nil, nil
+	}
+
+	if left(t.x) {
+		l, r = split(t.r, left)
+		return join(t.l, t.x, l), r
+	}
+
+	l, r = split(t.l, left)
+	return l, join(r, t.x, t.r)
+}
+
+func (t *setNode) first() Interface {
+	for t.l != nil {
+		t = t.l
+	}
+	return t.x
+}
+
+func (t *setNode) last() Interface {
+	for t.r != nil {
+		t = t.r
+	}
+	return t.x
+}
+
+// Len returns the number of members of s.
+func (s Set) Len() int { return size(s.root) }
+
+// Add returns s with the values of x added.
+func (s Set) Add(x Interface) Set {
+	if x.Class() == Empty {
+		return s
+	}
+
+	lo, hi := lower(x), upper(x)
+	l, m := split(s.root, func(y Interface) bool { return compareEdges(upper(y), lo) < 0 })
+	m, r := split(m, func(y Interface) bool { return compareEdges(lower(y), hi) <= 0 })
+	if m != nil {
+		if e := lower(m.first()); compareEdges(e, lo) < 0 {
+			lo = e
+		}
+		if e := upper(m.last()); compareEdges(e, hi) > 0 {
+			hi = e
+		}
+	}
+	return Set{join(l, span(x, lo, hi), r)}
+}
+
+// Remove returns s with the values of x removed.
+func (s Set) Remove(x Interface) Set {
+	if x.Class() == Empty {
+		return s
+	}
+
+	lo, hi := lower(x), upper(x)
+	l, m := split(s.root, func(y Interface) bool { return compareEdges(upper(y), lo) <= 0 })
+	m, r := split(m, func(y Interface) bool { return compareEdges(lower(y), hi) < 0 })
+	if m != nil {
+		if y := m.first(); compareEdges(lower(y), lo) < 0 {
+			l = join(l, span(y, lower(y), lo), nil)
+		}
+		if y := m.last(); compareEdges(upper(y), hi) > 0 {
+			r = join(nil, span(y, hi, upper(y)), r)
+		}
+	}
+	return Set{join2(l, r)}
+}
+
+// search returns the first member of s not lying completely before e or nil
+// if there is no such member.
+func (s Set) search(e edge) (r Interface) {
+	for t := s.root; t != nil; {
+		switch {
+		case compareEdges(upper(t.x), e) > 0:
+			r, t = t.x, t.l
+		default:
+			t = t.r
+		}
+	}
+	return r
+}
+
+// Contains reports whether all values of x are in s. An Empty x is contained
+// in any Set.
+func (s Set) Contains(x Interface) bool {
+	if x.Class() == Empty {
+		return true
+	}
+
+	y := s.search(lower(x))
+	return y != nil && compareEdges(lower(y), lower(x)) <= 0 && compareEdges(upper(y), upper(x)) >= 0
+}
+
+// Overlaps reports whether any value of x is in s.
+func (s Set) Overlaps(x Interface) bool {
+	if x.Class() == Empty {
+		return false
+	}
+
+	y := s.search(lower(x))
+	return y != nil && compareEdges(lower(y), upper(x)) < 0
+}
+
+// Overlapping returns an iterator over the members of s overlapping x in
+// ascending order.
+func (s Set) Overlapping(x Interface) iter.Seq[Interface] {
+	return func(yield func(Interface) bool) {
+		if x.Class() == Empty {
+			return
+		}
+
+		lo, hi := lower(x), upper(x)
+		var walk func(t *setNode) bool
+		walk = func(t *setNode) bool {
+			if t == nil {
+				return true
+			}
+
+			if compareEdges(upper(t.x), lo) <= 0 {
+				return walk(t.r)
+			}
+
+			if !walk(t.l) {
+				return false
+			}
+
+			if compareEdges(lower(t.x), hi) >= 0 {
+				return true
+			}
+
+			return yield(t.x.Clone()) && walk(t.r)
+		}
+		walk(s.root)
+	}
+}
+
+// All returns an iterator over the members of s in ascending order.
+func (s Set) All() iter.Seq[Interface] {
+	return func(yield func(Interface) bool) {
+		var walk func(t *setNode) bool
+		walk = func(t *setNode) bool {
+			return t == nil || walk(t.l) && yield(t.x.Clone()) && walk(t.r)
+		}
+		walk(s.root)
+	}
+}
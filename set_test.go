@@ -0,0 +1,138 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// The values of randInterval bounds lie within (setMin, setMax).
+const (
+	setMin = negInf
+	setMax = posInf + 20
+)
+
+// checkSet verifies the invariants of s and that it contains the values in
+// model and no others.
+func checkSet(t *testing.T, s Set, model map[int]bool) {
+	t.Helper()
+	var walk func(n *setNode) int
+	walk = func(n *setNode) int {
+		if n == nil {
+			return 0
+		}
+
+		hl, hr := walk(n.l), walk(n.r)
+		if hl-hr > 1 || hr-hl > 1 || n.h != max(hl, hr)+1 || n.n != size(n.l)+size(n.r)+1 {
+			t.Fatalf("%v: not balanced", n.x)
+		}
+
+		return n.h
+	}
+	walk(s.root)
+	var prev Interface
+	for x := range s.All() {
+		if x.Class() == Empty {
+			t.Fatal("empty member")
+		}
+
+		if prev != nil && compareEdges(upper(prev), lower(x)) >= 0 {
+			t.Fatalf("%v %v: not sorted, overlapping or touching", prev, x)
+		}
+
+		prev = x
+	}
+	for n := setMin; n <= setMax; n++ {
+		p := &interval{Degenerate, n, 0}
+		if g, e := s.Contains(p), model[n]; g != e {
+			t.Fatalf("%v: got %v, expected %v", n, g, e)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 300; i++ {
+		var versions []Set
+		var models []map[int]bool
+		var s Set
+		model := map[int]bool{}
+		for j := 0; j < 30; j++ {
+			x := randInterval(rng)
+			add := rng.Intn(3) != 0
+			switch {
+			case add:
+				s = s.Add(x)
+			default:
+				s = s.Remove(x)
+			}
+			m := map[int]bool{}
+			for n := setMin; n <= setMax; n++ {
+				m[n] = model[n]
+				if x.has(n) {
+					m[n] = add
+				}
+			}
+			model = m
+			checkSet(t, s, model)
+			versions = append(versions, s)
+			models = append(models, model)
+
+			q := randInterval(rng)
+			var overlaps bool
+			contains := true
+			for n := setMin; n <= setMax; n++ {
+				if q.has(n) {
+					overlaps = overlaps || model[n]
+					contains = contains && model[n]
+				}
+			}
+			if g, e := s.Overlaps(q), overlaps; g != e {
+				t.Fatalf("%v: overlaps got %v, expected %v", q, g, e)
+			}
+
+			if g, e := s.Contains(q), contains; g != e {
+				t.Fatalf("%v: contains got %v, expected %v", q, g, e)
+			}
+
+			var g, e []string
+			for y := range s.Overlapping(q) {
+				g = append(g, fmt.Sprint(y))
+			}
+			for y := range s.All() {
+				if Intersection(y, q).Class() != Empty {
+					e = append(e, fmt.Sprint(y))
+				}
+			}
+			if fmt.Sprint(g) != fmt.Sprint(e) {
+				t.Fatalf("%v: overlapping got %v, expected %v", q, g, e)
+			}
+		}
+		for j, s := range versions {
+			checkSet(t, s, models[j])
+		}
+	}
+}
+
+func ExampleSet() {
+	var s Set
+	s = s.Add(&Int64{Closed, 1, 5})
+	snapshot := s
+	s = s.Add(&Int64{LeftClosed, 5, 9}).Remove(&Int64{Open, 2, 4})
+	for x := range snapshot.All() {
+		fmt.Println(x)
+	}
+	fmt.Println()
+	for x := range s.All() {
+		fmt.Println(x)
+	}
+	// Output:
+	// [1, 5]
+	//
+	// [1, 2]
+	// [4, 9)
+}
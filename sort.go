@@ -0,0 +1,52 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import "sort"
+
+var _ sort.Interface = Slice(nil)
+
+// Compare returns -1, 0 or 1 when x orders before, equal to or after y, which
+// must have the same concrete type.
+//
+// Empty intervals order first. Other intervals are ordered by their lower
+// bound, where -∞ orders first and a closed bound orders before an open one
+// having the same value. Ties are broken by the upper bound, where an open
+// bound orders before a closed one having the same value and ∞ orders last.
+// For example
+//
+//	{} < (-∞, 1) < [1, 2) < [1, 2] < [1, ∞) < (1, 2)
+//
+// Compare is suitable for slices.SortFunc.
+func Compare(x, y Interface) int {
+	xe, ye := x.Class() == Empty, y.Class() == Empty
+	switch {
+	case xe && ye:
+		return 0
+	case xe:
+		return -1
+	case ye:
+		return 1
+	}
+
+	if n := compareEdges(lower(x), lower(y)); n != 0 {
+		return n
+	}
+
+	return compareEdges(upper(x), upper(y))
+}
+
+// Slice attaches the methods of sort.Interface to []Interface, sorting in the
+// order defined by Compare.
+type Slice []Interface
+
+// Len implements sort.Interface.
+func (s Slice) Len() int { return len(s) }
+
+// Less implements sort.Interface.
+func (s Slice) Less(i, j int) bool { return Compare(s[i], s[j]) < 0 }
+
+// Swap implements sort.Interface.
+func (s Slice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,94 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import "fmt"
+
+// Side selects the piece receiving the cut point in Split and SplitAll.
+type Side int
+
+// Values of Side.
+const (
+	Left  Side = iota // The cut point belongs to the left piece.
+	Right             // The cut point belongs to the right piece.
+)
+
+// (-∞, p) for p having A, like a Degenerate interval.
+func lessThan(p Interface) Interface { return setClass(setBA(p.Clone(), p), RightBoundedOpen) }
+
+// (-∞, p] for p having A.
+func atMost(p Interface) Interface { return setClass(setBA(p.Clone(), p), RightBoundedClosed) }
+
+// (p, ∞) for p having A.
+func greaterThan(p Interface) Interface { return setClass(p.Clone(), LeftBoundedOpen) }
+
+// [p, ∞) for p having A.
+func atLeast(p Interface) Interface { return setClass(p.Clone(), LeftBoundedClosed) }
+
+// Split cuts x at the point at, which must be a Degenerate interval of the same
+// type as x, and returns the parts of x lying left and right of the cut. If the
+// cut point is in x, side selects the piece that gets it. Any of the pieces may
+// be Empty.
+func Split(x, at Interface, side Side) (left, right Interface) {
+	if c := at.Class(); c != Degenerate {
+		panic(fmt.Errorf("interval: Split: cut point is %v, not Degenerate", c))
+	}
+
+	switch side {
+	case Left:
+		return Intersection(x, atMost(at)), Intersection(x, greaterThan(at))
+	case Right:
+		return Intersection(x, lessThan(at)), Intersection(x, atLeast(at))
+	}
+	panic(fmt.Errorf("interval: Split: invalid side %v", side))
+}
+
+// SplitAll cuts x at all of cuts, which must be Degenerate intervals of the
+// same type as x sorted in ascending order, and returns the len(cuts)+1
+// resulting pieces from left to right. The piece i lies between cuts[i-1] and
+// cuts[i]. Pieces containing no part of x are Empty. A cut point in x belongs
+// to the piece selected by side.
+func SplitAll(x Interface, cuts []Interface, side Side) []Interface {
+	r := make([]Interface, 0, len(cuts)+1)
+	for i, c := range cuts {
+		if i != 0 && c.CompareAA(cuts[i-1]) < 0 {
+			panic(fmt.Errorf("interval: SplitAll: cuts not sorted: %v > %v", cuts[i-1], c))
+		}
+
+		var left Interface
+		left, x = Split(x, c, side)
+		r = append(r, left)
+	}
+	return append(r, x)
+}
+
+// Difference returns the values of x not in y as up to two disjoint, non
+// empty intervals in ascending order. For example [1, 5] minus [2, 3) is [1,
+// 2) and [3, 5].
+func Difference(x, y Interface) []Interface {
+	if x.Class() == Empty {
+		return nil
+	}
+
+	if y.Class() == Empty {
+		return []Interface{x.Clone()}
+	}
+
+	var r []Interface
+	lo, hi := lower(x), upper(x)
+	if e := lower(y); compareEdges(lo, e) < 0 {
+		if compareEdges(e, hi) > 0 {
+			e = hi
+		}
+		r = append(r, span(x, lo, e))
+	}
+	if e := upper(y); compareEdges(e, hi) < 0 {
+		if compareEdges(e, lo) < 0 {
+			e = lo
+		}
+		r = append(r, span(x, e, hi))
+	}
+	return r
+}
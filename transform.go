@@ -0,0 +1,328 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"time"
+)
+
+// Errors returned by the transforms.
+var (
+	ErrMargin   = errors.New("interval: negative margin")
+	ErrOverflow = errors.New("interval: overflow")
+)
+
+// Number is satisfied by the bound types of the built-in numeric types,
+// including time.Duration. Int128, BigInt and BigRat do not satisfy Number.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+func isFloat[B Number]() bool { return B(1)/2 != 0 }
+
+// add returns a+d and false on overflow.
+func add[B Number](a, d B) (B, bool) {
+	r := a + d
+	return r, !(d > 0 && r < a || d < 0 && r > a)
+}
+
+// sub returns a-d and false on overflow.
+func sub[B Number](a, d B) (B, bool) {
+	r := a - d
+	return r, !(d > 0 && r > a || d < 0 && r < a)
+}
+
+func isSigned[B Number]() bool { return B(0)-1 < 0 }
+
+// toBig returns the integer v as a big.Int.
+func toBig[B Number](v B) *big.Int {
+	if isSigned[B]() {
+		return big.NewInt(int64(v))
+	}
+
+	return big.NewInt(0).SetUint64(uint64(v))
+}
+
+// fromBig returns n as a B and false if B cannot represent n.
+func fromBig[B Number](n *big.Int) (B, bool) {
+	if isSigned[B]() {
+		if !n.IsInt64() {
+			return 0, false
+		}
+
+		r := B(n.Int64())
+		return r, int64(r) == n.Int64()
+	}
+
+	if !n.IsUint64() {
+		return 0, false
+	}
+
+	r := B(n.Uint64())
+	return r, uint64(r) == n.Uint64()
+}
+
+// scale returns c+(v-c)*k and false on overflow. Integers are scaled in
+// big.Int, so only the result must fit in B.
+func scale[B Number](v, c, k B) (B, bool) {
+	if isFloat[B]() {
+		return c + (v-c)*k, true
+	}
+
+	r := toBig(v)
+	r.Sub(r, toBig(c))
+	r.Mul(r, toBig(k))
+	return fromBig[B](r.Add(r, toBig(c)))
+}
+
+// mirror maps the class of an interval to the class of its image by a
+// decreasing function.
+func mirror(c Class) Class {
+	switch c {
+	case LeftOpen:
+		return LeftClosed
+	case LeftClosed:
+		return LeftOpen
+	case LeftBoundedOpen:
+		return RightBoundedOpen
+	case LeftBoundedClosed:
+		return RightBoundedClosed
+	case RightBoundedOpen:
+		return LeftBoundedOpen
+	case RightBoundedClosed:
+		return LeftBoundedClosed
+	}
+	return c
+}
+
+// Translate returns x shifted by d. For unsigned bound types only shifting to
+// the right is expressible. If any bound overflows, ErrOverflow is returned.
+func Translate[T Concrete[B], B Number, P Pointer[T]](x *T, d B) (*T, error) {
+	s := bounds[B](*x)
+	var ok bool
+	if hasA(s.Cls) {
+		if s.A, ok = add(s.A, d); !ok {
+			return nil, ErrOverflow
+		}
+	}
+	if hasB(s.Cls) {
+		if s.B, ok = add(s.B, d); !ok {
+			return nil, ErrOverflow
+		}
+	}
+	return New[T, B, P](s.Cls, s.A, s.B)
+}
+
+// Scale returns the image of x by the mapping v -> c+(v-c)*k, that is x scaled
+// by the factor k about the point c. A negative k mirrors x, for example
+// (1, 2] scaled by -1 about 0 is [-2, -1). A zero k collapses non empty
+// intervals to {c}. If any bound overflows, ErrOverflow is returned.
+func Scale[T Concrete[B], B Number, P Pointer[T]](x *T, c, k B) (*T, error) {
+	s := bounds[B](*x)
+	switch {
+	case s.Cls == Empty:
+		// nop
+	case k == 0:
+		s = bounds[B]{Cls: Degenerate, A: c}
+	default:
+		var ok bool
+		if hasA(s.Cls) {
+			if s.A, ok = scale(s.A, c, k); !ok {
+				return nil, ErrOverflow
+			}
+		}
+		if hasB(s.Cls) {
+			if s.B, ok = scale(s.B, c, k); !ok {
+				return nil, ErrOverflow
+			}
+		}
+		if k < 0 {
+			s.Cls = mirror(s.Cls)
+			s.A, s.B = s.B, s.A
+			if s.Cls == Degenerate {
+				s.A = s.B
+			}
+		}
+	}
+	return New[T, B, P](s.Cls, s.A, s.B)
+}
+
+// Expand returns x with both of its bounds moved outwards by the margin m, for
+// example [1, 2) expanded by 1 is [0, 3). A Degenerate {a} becomes [a-m, a+m].
+// If any bound overflows, ErrOverflow is returned. A negative m is reported as
+// ErrMargin.
+func Expand[T Concrete[B], B Number, P Pointer[T]](x *T, m B) (*T, error) {
+	if m < 0 {
+		return nil, ErrMargin
+	}
+
+	s := bounds[B](*x)
+	if s.Cls == Degenerate && m != 0 {
+		s.Cls, s.B = Closed, s.A
+	}
+	var ok bool
+	if hasA(s.Cls) {
+		if s.A, ok = sub(s.A, m); !ok {
+			return nil, ErrOverflow
+		}
+	}
+	if hasB(s.Cls) {
+		if s.B, ok = add(s.B, m); !ok {
+			return nil, ErrOverflow
+		}
+	}
+	return New[T, B, P](s.Cls, s.A, s.B)
+}
+
+// Shrink returns x with both of its bounds moved inwards by the margin m, for
+// example [1, 5) shrunk by 1 is [2, 4). When the bounds meet, the result is
+// Degenerate for Closed intervals and Empty otherwise. When they cross, the
+// result is Empty. If the only bound of a half-bounded interval overflows,
+// ErrOverflow is returned. A negative m is reported as ErrMargin.
+func Shrink[T Concrete[B], B Number, P Pointer[T]](x *T, m B) (*T, error) {
+	if m < 0 {
+		return nil, ErrMargin
+	}
+
+	s := bounds[B](*x)
+	if s.Cls == Degenerate && m != 0 {
+		s.Cls = Empty
+	}
+	a, okA := add(s.A, m)
+	b, okB := sub(s.B, m)
+	switch {
+	case hasA(s.Cls) && hasB(s.Cls):
+		if !okA || !okB || a > b {
+			s.Cls = Empty
+			break
+		}
+
+		s.A, s.B = a, b
+	case hasA(s.Cls) && s.Cls != Degenerate:
+		if !okA {
+			return nil, ErrOverflow
+		}
+
+		s.A = a
+	case hasB(s.Cls):
+		if !okB {
+			return nil, ErrOverflow
+		}
+
+		s.B = b
+	}
+	return New[T, B, P](s.Cls, s.A, s.B)
+}
+
+// Translate returns i shifted by d.
+func (i *Time) Translate(d time.Duration) (*Time, error) {
+	s := *i
+	s.A = s.A.Add(d)
+	s.B = s.B.Add(d)
+	return New[Time](s.Cls, s.A, s.B)
+}
+
+// Scale returns the image of i by the mapping t -> c+(t-c)*k, that is i scaled
+// by the factor k about the time c. See the Scale function for details.
+func (i *Time) Scale(c time.Time, k float64) (*Time, error) {
+	s := *i
+	switch {
+	case s.Cls == Empty:
+		// nop
+	case k == 0:
+		s = Time{Cls: Degenerate, A: c}
+	default:
+		var ok bool
+		if hasA(s.Cls) {
+			if s.A, ok = scaleTime(s.A, c, k); !ok {
+				return nil, ErrOverflow
+			}
+		}
+		if hasB(s.Cls) {
+			if s.B, ok = scaleTime(s.B, c, k); !ok {
+				return nil, ErrOverflow
+			}
+		}
+		if k < 0 {
+			s.Cls = mirror(s.Cls)
+			s.A, s.B = s.B, s.A
+			if s.Cls == Degenerate {
+				s.A = s.B
+			}
+		}
+	}
+	return New[Time](s.Cls, s.A, s.B)
+}
+
+// nanos returns t as the number of nanoseconds since the Unix epoch.
+func nanos(t time.Time) *big.Int {
+	n := big.NewInt(t.Unix())
+	n.Mul(n, big.NewInt(1e9))
+	return n.Add(n, big.NewInt(int64(t.Nanosecond())))
+}
+
+// fromNanos returns the time n nanoseconds after the Unix epoch in loc and
+// false if time.Time cannot represent it.
+func fromNanos(n *big.Int, loc *time.Location) (time.Time, bool) {
+	sec, nsec := big.NewInt(0).DivMod(n, big.NewInt(1e9), big.NewInt(0))
+	if !sec.IsInt64() {
+		return time.Time{}, false
+	}
+
+	t := time.Unix(sec.Int64(), nsec.Int64()).In(loc)
+	return t, nanos(t).Cmp(n) == 0
+}
+
+// scaleTime returns c+(t-c)*k and false if the result is not representable.
+func scaleTime(t, c time.Time, k float64) (time.Time, bool) {
+	if math.IsNaN(k) || math.IsInf(k, 0) {
+		return t, false
+	}
+
+	n := nanos(t)
+	n.Sub(n, nanos(c))
+	n, _ = big.NewFloat(0).Mul(big.NewFloat(0).SetInt(n), big.NewFloat(k)).Int(n)
+	return fromNanos(n.Add(n, nanos(c)), c.Location())
+}
+
+// Expand returns i with both of its bounds moved outwards by m. See the Expand
+// function for details.
+func (i *Time) Expand(m time.Duration) (*Time, error) {
+	if m < 0 {
+		return nil, ErrMargin
+	}
+
+	s := *i
+	if s.Cls == Degenerate && m != 0 {
+		s.Cls, s.B = Closed, s.A
+	}
+	s.A = s.A.Add(-m)
+	s.B = s.B.Add(m)
+	return New[Time](s.Cls, s.A, s.B)
+}
+
+// Shrink returns i with both of its bounds moved inwards by m. See the Shrink
+// function for details.
+func (i *Time) Shrink(m time.Duration) (*Time, error) {
+	if m < 0 {
+		return nil, ErrMargin
+	}
+
+	s := *i
+	if s.Cls == Degenerate && m != 0 {
+		s.Cls = Empty
+	}
+	s.A = s.A.Add(m)
+	s.B = s.B.Add(-m)
+	if hasA(s.Cls) && hasB(s.Cls) && s.A.After(s.B) {
+		s.Cls = Empty
+	}
+	return New[Time](s.Cls, s.A, s.B)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import "reflect"
+
+// check returns a *TypeError if x and y have different concrete types or the
+// first error reported by Validate for x or y.
+func check(x, y Interface) error {
+	if reflect.TypeOf(x) != reflect.TypeOf(y) {
+		return &TypeError{x, y}
+	}
+
+	if err := Validate(x); err != nil {
+		return err
+	}
+
+	return Validate(y)
+}
+
+// TryIntersection is like Intersection but it returns an error instead of
+// panicking or producing garbage when x and y have different concrete types
+// (*TypeError) or when any of them is not valid (*InvalidError).
+func TryIntersection(x, y Interface) (Interface, error) {
+	if err := check(x, y); err != nil {
+		return nil, err
+	}
+
+	return Intersection(x, y), nil
+}
+
+// TryUnion is like Union but it returns an error instead of panicking or
+// producing garbage when x and y have different concrete types (*TypeError) or
+// when any of them is not valid (*InvalidError). If the union is not an
+// interval, TryUnion returns (nil, nil).
+func TryUnion(x, y Interface) (Interface, error) {
+	if err := check(x, y); err != nil {
+		return nil, err
+	}
+
+	return Union(x, y), nil
+}
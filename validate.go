@@ -0,0 +1,186 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Problems reported by Validate and NewArc. They are wrapped in an
+// *InvalidError, use errors.Is to test for them.
+var (
+	ErrClass = errors.New("invalid class")
+	ErrDate  = errors.New("bound is not a valid date")
+	ErrEqual = errors.New("a == b")
+	ErrNaN   = errors.New("bound is NaN")
+	ErrNil   = errors.New("bound is nil")
+	ErrOrder = errors.New("a > b")
+	ErrRange = errors.New("bound out of range")
+)
+
+// InvalidError describes an interval violating its invariants.
+type InvalidError struct {
+	Class Class  // Class of the invalid interval.
+	Bound string // "a", "b" or "" if the problem is not related to a single bound.
+	Err   error  // One of ErrClass, ErrDate, ErrEqual, ErrNaN, ErrNil, ErrOrder or ErrRange.
+}
+
+// Error implements error.
+func (e *InvalidError) Error() string {
+	if e.Bound != "" {
+		return fmt.Sprintf("interval: invalid %v interval: %s: %v", e.Class, e.Bound, e.Err)
+	}
+
+	return fmt.Sprintf("interval: invalid %v interval: %v", e.Class, e.Err)
+}
+
+// Unwrap returns e.Err.
+func (e *InvalidError) Unwrap() error { return e.Err }
+
+// Validate checks that x obeys the invariants of its class: the class must be
+// valid and proper intervals must have a < b. Concrete types check their
+// bounds as well, for example NaN bounds of Float64 or nil bounds of BigInt are
+// reported. Bounds not used by the class of x are not checked.
+//
+// Any error returned is an *InvalidError.
+func Validate(x Interface) error {
+	if v, ok := x.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+
+	return validate(x)
+}
+
+func validate(x Interface) error {
+	c := x.Class()
+	if c < 0 || c >= nClasses {
+		return &InvalidError{Class: c, Err: ErrClass}
+	}
+
+	if hasA(c) && hasB(c) {
+		switch n := x.CompareAB(x); {
+		case n > 0:
+			return &InvalidError{Class: c, Err: ErrOrder}
+		case n == 0:
+			return &InvalidError{Class: c, Err: ErrEqual}
+		}
+	}
+	return nil
+}
+
+// validateBound reports bad, if true, as a problem of the bound named by bound
+// of an interval of class c, provided the class has that bound.
+func validateBound(c Class, bound string, bad bool, err error) error {
+	if !bad || c < 0 || c >= nClasses {
+		return nil
+	}
+
+	if bound == "a" && hasA(c) || bound == "b" && hasB(c) {
+		return &InvalidError{Class: c, Bound: bound, Err: err}
+	}
+
+	return nil
+}
+
+func validateFloat(x Interface, a, b float64) error {
+	if err := validateBound(x.Class(), "a", math.IsNaN(a), ErrNaN); err != nil {
+		return err
+	}
+
+	if err := validateBound(x.Class(), "b", math.IsNaN(b), ErrNaN); err != nil {
+		return err
+	}
+
+	return validate(x)
+}
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Float32) Validate() error { return validateFloat(i, float64(i.A), float64(i.B)) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Float64) Validate() error { return validateFloat(i, i.A, i.B) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Int8) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Int16) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Int32) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Int64) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Int128) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Int) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Byte) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Uint16) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Uint32) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Uint64) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Uint) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *String) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Time) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Duration) Validate() error { return validate(i) }
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *Date) Validate() error {
+	if err := validateBound(i.Cls, "a", !i.A.IsValid(), ErrDate); err != nil {
+		return err
+	}
+
+	if err := validateBound(i.Cls, "b", !i.B.IsValid(), ErrDate); err != nil {
+		return err
+	}
+
+	return validate(i)
+}
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *BigInt) Validate() error {
+	if err := validateBound(i.Cls, "a", i.A == nil, ErrNil); err != nil {
+		return err
+	}
+
+	if err := validateBound(i.Cls, "b", i.B == nil, ErrNil); err != nil {
+		return err
+	}
+
+	return validate(i)
+}
+
+// Validate checks the invariants of i. See the Validate function for details.
+func (i *BigRat) Validate() error {
+	if err := validateBound(i.Cls, "a", i.A == nil, ErrNil); err != nil {
+		return err
+	}
+
+	if err := validateBound(i.Cls, "b", i.B == nil, ErrNil); err != nil {
+		return err
+	}
+
+	return validate(i)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+	"time"
+)
+
+// firstWindow returns origin+k*step for k = ⌊(t-origin-size)/step⌋, the start
+// of the window before the first one overlapping t. The arithmetic is not
+// limited by the range of time.Duration.
+func firstWindow(t, origin time.Time, size, step time.Duration) time.Time {
+	d := nanos(t)
+	d.Sub(d, nanos(origin))
+	d.Sub(d, big.NewInt(int64(size)))
+	d.Div(d, big.NewInt(int64(step))) // Euclidean, that is floor for step > 0.
+	d.Mul(d, big.NewInt(int64(step)))
+	r, _ := fromNanos(d.Add(d, nanos(origin)), origin.Location())
+	return r
+}
+
+// Tumbling returns an iterator over the windows [origin+k*size,
+// origin+(k+1)*size), for any integer k, overlapping x in ascending order. See
+// Hopping for details.
+func Tumbling(x *Time, size time.Duration, origin time.Time) iter.Seq[*Time] {
+	return Hopping(x, size, size, origin)
+}
+
+// Hopping returns an iterator over the windows [origin+k*step,
+// origin+k*step+size), for any integer k, overlapping x in ascending order.
+// Windows overlap when step < size, those are also known as sliding windows,
+// and leave gaps when step > size. If x has no upper bound, the iteration
+// never ends on its own. Hopping panics if size or step are not positive or if
+// x is not Empty and has no lower bound.
+func Hopping(x *Time, size, step time.Duration, origin time.Time) iter.Seq[*Time] {
+	if size <= 0 || step <= 0 {
+		panic(fmt.Errorf("interval: Hopping: invalid size %v or step %v", size, step))
+	}
+
+	if x.Cls != Empty && !hasA(x.Cls) {
+		panic(fmt.Errorf("interval: Hopping: %v has no lower bound", x))
+	}
+
+	return func(yield func(*Time) bool) {
+		if x.Cls == Empty {
+			return
+		}
+
+		hi := upper(x)
+		for a := firstWindow(x.A, origin, size, step); ; a = a.Add(step) {
+			w := &Time{LeftClosed, a, a.Add(size)}
+			if compareEdges(lower(w), hi) >= 0 {
+				return
+			}
+
+			if Intersection(w, x).Class() != Empty && !yield(w) {
+				return
+			}
+		}
+	}
+}
+
+// Fraction returns the part of the length of bucket covered by x, a number in
+// [0, 1]. Fraction panics if bucket is not bounded or has a zero length.
+func Fraction(x, bucket *Time) float64 {
+	l, ok := length(bucket)
+	if bucket.Cls == Empty || !ok || l == 0 {
+		panic(fmt.Errorf("interval: Fraction: invalid bucket %v", bucket))
+	}
+
+	y := Intersection(x, bucket).(*Time)
+	if y.Cls == Empty {
+		return 0
+	}
+
+	n, _ := length(y)
+	return float64(n) / float64(l)
+}
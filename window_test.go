@@ -0,0 +1,93 @@
+// Copyright (c) 2015 The Interval Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestHopping(t *testing.T) {
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	minute := func(m int) time.Time { return origin.Add(time.Duration(m) * time.Minute) }
+	str := func(s func(func(*Time) bool)) (r []string) {
+		for w := range s {
+			r = append(r, fmt.Sprintf("%v-%v", w.A.Sub(origin).Minutes(), w.B.Sub(origin).Minutes()))
+		}
+		return r
+	}
+	for _, test := range []struct {
+		x          *Time
+		size, step int
+		e          string
+	}{
+		{&Time{Cls: Empty}, 10, 10, "[]"},
+		{&Time{LeftClosed, minute(5), minute(25)}, 10, 10, "[0-10 10-20 20-30]"},
+		{&Time{LeftClosed, minute(10), minute(20)}, 10, 10, "[10-20]"},
+		{&Time{Closed, minute(10), minute(20)}, 10, 10, "[10-20 20-30]"},
+		{&Time{Open, minute(10), minute(20)}, 10, 10, "[10-20]"},
+		{&Time{Degenerate, minute(-10), minute(-10)}, 10, 10, "[-10-0]"},
+		{&Time{Degenerate, minute(-15), minute(-15)}, 10, 10, "[-20--10]"},
+		{&Time{LeftClosed, minute(12), minute(14)}, 10, 5, "[5-15 10-20]"},
+		{&Time{LeftClosed, minute(12), minute(14)}, 5, 10, "[10-15]"},
+		{&Time{LeftClosed, minute(16), minute(19)}, 5, 10, "[]"},
+		{&Time{Closed, minute(15), minute(20)}, 5, 10, "[20-25]"},
+	} {
+		if g, e := fmt.Sprint(str(Hopping(test.x, time.Duration(test.size)*time.Minute, time.Duration(test.step)*time.Minute, origin))), test.e; g != e {
+			t.Fatalf("%v %v %v: got %v, expected %v", test.x, test.size, test.step, g, e)
+		}
+	}
+
+	// Origins more than about 292 years from x, the range of time.Duration.
+	for _, test := range []struct {
+		size, step int
+		origin     time.Time
+		e          string
+	}{
+		{60, 60, time.Time{}, "[600-660 660-720 720-780]"},
+		{120, 60, time.Date(-1000000, 1, 1, 0, 0, 0, 0, time.UTC), "[540-660 600-720 660-780 720-840]"},
+		{60, 60, time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC), "[600-660 660-720 720-780]"},
+	} {
+		x := &Time{LeftClosed, minute(630), minute(750)}
+		if g, e := fmt.Sprint(str(Hopping(x, time.Duration(test.size)*time.Minute, time.Duration(test.step)*time.Minute, test.origin))), test.e; g != e {
+			t.Fatalf("%v %v %v: got %v, expected %v", test.origin, test.size, test.step, g, e)
+		}
+	}
+
+	var n int
+	for range Tumbling(&Time{LeftBoundedClosed, minute(0), time.Time{}}, time.Minute, origin) {
+		if n++; n == 100 {
+			break
+		}
+	}
+
+	for _, test := range []struct {
+		x *Time
+		e float64
+	}{
+		{&Time{Cls: Empty}, 0},
+		{&Time{Unbounded, minute(0), minute(0)}, 1},
+		{&Time{Degenerate, minute(5), minute(5)}, 0},
+		{&Time{Open, minute(5), minute(20)}, 0.5},
+		{&Time{RightBoundedOpen, minute(0), minute(1)}, 0.1},
+	} {
+		if g, e := Fraction(test.x, &Time{LeftClosed, minute(0), minute(10)}), test.e; g != e {
+			t.Fatalf("%v: got %v, expected %v", test.x, g, e)
+		}
+	}
+}
+
+func ExampleTumbling() {
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	x := &Time{LeftClosed, origin.Add(50 * time.Minute), origin.Add(140 * time.Minute)}
+	for w := range Tumbling(x, time.Hour, origin) {
+		fmt.Println(w.A.Format("15:04"), w.B.Format("15:04"), Fraction(x, w))
+	}
+	// Output:
+	// 00:00 01:00 0.16666666666666666
+	// 01:00 02:00 1
+	// 02:00 03:00 0.3333333333333333
+}